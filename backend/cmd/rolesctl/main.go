@@ -0,0 +1,200 @@
+// Command rolesctl checks a directory of role configurations before they are deployed.
+//
+// Every file is loaded and validated as the server would. For each configuration it prints
+// the cards dealt to each player count (6-30) when the host selects no roles: the
+// configured counts, or the selection auto-fix makes of them if they cannot be dealt as
+// they are. Those cards are then dealt many times with a seeded random source, and deals
+// that break the game's rules are reported, such as a team without its leader or Red and
+// Blue teams of unequal size.
+//
+// It exits with status 1 if a file is invalid, a player count cannot be dealt as configured or
+// a simulated deal breaks a rule, so it can run as a pre-merge check:
+//
+//	go run ./cmd/rolesctl -dir config/roles
+//
+// With -strict=false player counts that auto-fix can deal are accepted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/services"
+)
+
+// Player counts a configuration is checked for
+const (
+	minPlayers = 6
+	maxPlayers = 30
+)
+
+func main() {
+	dir := flag.String("dir", config.DefaultDir(), "directory of role configuration files (default $ROLE_CONFIG_DIR)")
+	configID := flag.String("config", "", "check only this configuration")
+	runs := flag.Int("runs", 1000, "simulated deals per configuration and player count (0 skips the simulation)")
+	seed := flag.Int64("seed", 1, "random seed for the simulated deals")
+	strict := flag.Bool("strict", true, "fail when a player count's configured counts cannot be dealt as they are (false accepts what auto-fix can deal)")
+	flag.Parse()
+
+	os.Exit(run(os.Stdout, *dir, *configID, *runs, *seed, *strict))
+}
+
+// run checks the configurations and returns the exit status
+func run(out io.Writer, dir, configID string, runs int, seed int64, strict bool) int {
+	loader := config.NewRoleConfigLoader(dir)
+	if err := loader.LoadAll(); err != nil {
+		fmt.Fprintf(out, "Invalid role configurations in %s:\n%v\n", dir, err)
+		return 1
+	}
+
+	all := loader.GetAll()
+	ids := make([]string, 0, len(all))
+	for id := range all {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	if configID != "" {
+		if _, err := loader.Get(configID); err != nil {
+			fmt.Fprintf(out, "%v\n", err)
+			return 1
+		}
+		ids = []string{configID}
+	}
+	fmt.Fprintf(out, "Loaded %d role configuration(s) from %s\n", len(all), dir)
+
+	// Dealing logs every card; only the report is wanted here
+	log.SetOutput(io.Discard)
+
+	failed := false
+	for _, id := range ids {
+		roleConfig := all[id]
+		fmt.Fprintf(out, "\n== %s (%s, revision %d) ==\n", roleConfig.ID, roleConfig.Name, roleConfig.Revision)
+		deals := printDistributions(out, roleConfig)
+		if undealable := maxPlayers - minPlayers + 1 - len(deals); undealable > 0 {
+			fmt.Fprintf(out, "%d player count(s) cannot be dealt\n", undealable)
+			failed = true
+		}
+		if fixed := autoFixed(deals); len(fixed) > 0 {
+			fmt.Fprintf(out, "%d player count(s) need auto-fix: %s\n", len(fixed), strings.Join(fixed, ", "))
+			if strict {
+				failed = true
+			}
+		}
+		if runs > 0 && !simulate(out, loader, roleConfig.ID, deals, runs, seed) {
+			failed = true
+		}
+	}
+
+	if failed {
+		fmt.Fprintln(out, "\nFAIL")
+		return 1
+	}
+	fmt.Fprintln(out, "\nOK")
+	return 0
+}
+
+// deal is the role selection dealt to a player count when the host selects no roles
+type deal struct {
+	players   int
+	selection map[string]int // nil for the configured counts
+}
+
+// autoFixed lists the player counts that are only dealt after auto-fix
+func autoFixed(deals []deal) []string {
+	var players []string
+	for _, d := range deals {
+		if d.selection != nil {
+			players = append(players, fmt.Sprintf("%d", d.players))
+		}
+	}
+	return players
+}
+
+// printDistributions prints the cards dealt for each player count and returns the player
+// counts that can be dealt, with their selections
+func printDistributions(out io.Writer, roleConfig *config.RoleConfig) []deal {
+	var deals []deal
+	var notes []string
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "PLAYERS\tDEAL\tCARDS\tRED\tBLUE\tGREY\tZOMBIE\tROLES")
+	for players := minPlayers; players <= maxPlayers; players++ {
+		// Like starting a game with auto-fix: the configured counts, adjusted if needed
+		d := deal{players: players}
+		source := "configured"
+		plan := services.PlanRoleDistribution(roleConfig, players, nil)
+		if err := plan.Err(); err != nil {
+			fixed, _ := services.AutoFixRoleSelection(roleConfig, players, nil)
+			fixedPlan := services.PlanRoleDistribution(roleConfig, players, fixed)
+			if fixedPlan.Err() != nil {
+				fmt.Fprintf(table, "%d\t-\t-\t-\t-\t-\t-\tcannot be dealt: %v\n", players, err)
+				continue
+			}
+			d.selection, source, plan = fixed, "auto-fixed", fixedPlan
+		}
+		deals = append(deals, d)
+
+		cards := fmt.Sprintf("%d", plan.CardCount)
+		if plan.Buried {
+			cards += " (1 buried)"
+		}
+		sizes := make([]string, 0, len(plan.Teams))
+		var roles []string
+		for _, team := range plan.Teams {
+			sizes = append(sizes, fmt.Sprintf("%d", team.Size))
+			for _, role := range team.Roles {
+				roles = append(roles, fmt.Sprintf("%s×%d", role.RoleID, role.Count))
+			}
+			if team.Fill > 0 {
+				roles = append(roles, fmt.Sprintf("%s×%d", team.FillRoleID, team.Fill))
+			}
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", players, source, cards, strings.Join(sizes, "\t"), strings.Join(roles, ", "))
+
+		for _, warning := range plan.Warnings {
+			notes = append(notes, fmt.Sprintf("%d players: warning: %s", players, warning))
+		}
+	}
+	table.Flush()
+
+	for _, note := range notes {
+		fmt.Fprintln(out, note)
+	}
+	return deals
+}
+
+// simulate deals the configuration for each player count and reports rule violations
+// Returns false if any deal broke a rule.
+func simulate(out io.Writer, loader *config.RoleConfigLoader, configID string, deals []deal, runs int, seed int64) bool {
+	gameService := services.NewGameService(nil, loader)
+
+	ok := true
+	for _, d := range deals {
+		// Each player count has its own source, so results do not depend on -config
+		gameService.SetRand(rand.New(rand.NewSource(seed + int64(d.players))))
+		simulation, err := gameService.SimulateRoleAssignments(configID, d.players, d.selection, runs)
+		if err != nil {
+			fmt.Fprintf(out, "%d players: simulation failed: %v\n", d.players, err)
+			ok = false
+			continue
+		}
+		for _, violation := range simulation.Violations {
+			fmt.Fprintf(out, "%d players: %s (%d of %d runs, first in run %d)\n",
+				d.players, violation.Message, violation.Runs, simulation.Runs, violation.FirstRun)
+			ok = false
+		}
+	}
+
+	if ok {
+		fmt.Fprintf(out, "Simulated %d deal(s) for each of %d player count(s): no violations\n", runs, len(deals))
+	}
+	return ok
+}
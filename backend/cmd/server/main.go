@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -73,16 +74,35 @@ func main() {
 	})
 
 	// Initialize role configuration loader
-	roleConfigDir := os.Getenv("ROLE_CONFIG_DIR")
-	if roleConfigDir == "" {
-		roleConfigDir = "./backend/config/roles"
-	}
+	roleConfigDir := config.DefaultDir()
 	roleLoader := config.NewRoleConfigLoader(roleConfigDir)
 	if err := roleLoader.LoadAll(); err != nil {
 		log.Fatalf("[FATAL] Failed to load role configurations: %v", err)
 	}
 	log.Printf("[INFO] Loaded %d role configuration(s)", len(roleLoader.GetAll()))
 
+	// Reload role configurations on SIGHUP, and optionally when the directory changes
+	// (e.g. a Kubernetes ConfigMap update). Invalid changes are reported and the current set is kept.
+	reportReload := func(err error) {
+		if err != nil {
+			log.Printf("[ERROR] Role configuration reload failed, keeping current configurations: %v", err)
+			return
+		}
+		log.Printf("[INFO] Reloaded %d role configuration(s)", len(roleLoader.GetAll()))
+	}
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			reportReload(roleLoader.LoadAll())
+		}
+	}()
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if seconds, err := strconv.Atoi(os.Getenv("ROLE_CONFIG_RELOAD_SECONDS")); err == nil && seconds > 0 {
+		go roleLoader.Watch(watchCtx, time.Duration(seconds)*time.Second, reportReload)
+	}
+
 	// Initialize dependencies
 	roomStore := store.NewRoomStore()
 	hub := websocket.NewHub()
@@ -92,6 +112,8 @@ func main() {
 
 	// Initialize services
 	roomService := services.NewRoomService(roomStore)
+	roomService.SetRoleLoader(roleLoader)
+	roomService.SetHub(hub)
 	playerService := services.NewPlayerService(roomStore, hub)
 	gameService := services.NewGameService(roomStore, roleLoader)
 	gameService.SetHub(hub)
@@ -101,6 +123,8 @@ func main() {
 	leaderService := services.NewLeaderService(roomStore, hub)
 	votingService := services.NewVotingService(roomStore, hub, leaderService)
 	exchangeService := services.NewExchangeService(roomStore, hub, leaderService)
+	shareService := services.NewShareService(roomStore, hub)
+	powerService := services.NewPowerService(roomStore, hub)
 
 	// Wire round services to game service for automatic round start
 	gameService.SetRoundManager(roundManager)
@@ -109,6 +133,20 @@ func main() {
 	// Wire leader service to round manager for auto-assigning leaders on next round
 	roundManager.SetLeaderService(leaderService)
 
+	// Wire voting service to round manager so an early game end can cancel votes
+	roundManager.SetVotingService(votingService)
+
+	// Wire round manager to share service for card share powers that end the game
+	shareService.SetRoundManager(roundManager)
+
+	// Wire share service to power service for forced-share powers
+	powerService.SetShareService(shareService)
+
+	// Deadline for end-of-game choices after round 3 (Sniper, Gambler)
+	if seconds, err := strconv.Atoi(os.Getenv("FINAL_ACTION_SECONDS")); err == nil && seconds > 0 {
+		roundManager.SetFinalActionDuration(time.Duration(seconds) * time.Second)
+	}
+
 	// Initialize handlers
 	roomHandler := handlers.NewRoomHandler(roomService, roleLoader)
 	playerHandler := handlers.NewPlayerHandler(playerService)
@@ -116,6 +154,11 @@ func main() {
 	wsHandler := handlers.NewWebSocketHandler(hub, roomService, playerService)
 	roleConfigHandler := handlers.NewRoleConfigHandler(roleLoader)
 	roundHandler := handlers.NewRoundHandler(roundManager, leaderService, votingService, exchangeService)
+	shareHandler := handlers.NewShareHandler(shareService)
+	powerHandler := handlers.NewPowerHandler(powerService)
+
+	// Role configuration changes require ROLE_ADMIN_TOKEN; without it they are disabled
+	adminAuth := middleware.AdminAuth(os.Getenv("ROLE_ADMIN_TOKEN"))
 
 	// Health check endpoint
 	r.GET("/health", func(c *gin.Context) {
@@ -132,12 +175,17 @@ func main() {
 		// Role configuration routes
 		v1.GET("/role-configs", roleConfigHandler.ListRoleConfigs)
 		v1.GET("/role-configs/:id", roleConfigHandler.GetRoleConfig)
+		v1.POST("/role-configs/:id/preview", roleConfigHandler.PreviewRoleConfig)
+		v1.POST("/role-configs", adminAuth, roleConfigHandler.CreateRoleConfig)
+		v1.PUT("/role-configs/:id", adminAuth, roleConfigHandler.UpdateRoleConfig)
+		v1.DELETE("/role-configs/:id", adminAuth, roleConfigHandler.DeleteRoleConfig)
 
 		// Room routes
 		v1.GET("/rooms", middleware.RoomListLimiter.Middleware(), roomHandler.ListRooms)
 		v1.POST("/rooms", middleware.RoomCreationLimiter.Middleware(), roomHandler.CreateRoom)
 		v1.GET("/rooms/:roomCode", roomHandler.GetRoom)
 		v1.PATCH("/rooms/:roomCode/visibility", roomHandler.UpdateRoomVisibility)
+		v1.PUT("/rooms/:roomCode/role-config", roomHandler.UpdateRoleSet)
 
 		// Player routes
 		v1.POST("/rooms/:roomCode/players", middleware.RoomJoinLimiter.Middleware(), playerHandler.JoinRoom)
@@ -157,6 +205,14 @@ func main() {
 		v1.POST("/rooms/:roomCode/votes/:voteId/cast", roundHandler.CastVote)
 		v1.POST("/rooms/:roomCode/hostages/select", roundHandler.SelectHostages)
 		v1.POST("/rooms/:roomCode/rounds/ready", roundHandler.LeaderReady)
+		v1.POST("/rooms/:roomCode/final-actions", roundHandler.SubmitFinalAction)
+
+		// Card/color share routes
+		v1.POST("/rooms/:roomCode/shares", shareHandler.RequestShare)
+		v1.POST("/rooms/:roomCode/shares/:shareId/respond", shareHandler.RespondToShare)
+
+		// Role power routes
+		v1.POST("/rooms/:roomCode/powers/use", powerHandler.UsePower)
 	}
 
 	// WebSocket route
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/expr"
+)
+
+// CountExpressionSymbols lists the variables and functions a count formula can use
+//
+// Variables:
+//   - players: number of players in the game
+//
+// Division rounds down, so "players/5" and "floor(players/5)" are the same; round up
+// with "(players + 4) / 5".
+var CountExpressionSymbols = expr.Symbols{
+	Vars: []string{"players"},
+	Funcs: map[string]int{
+		"floor": 1,  // floor(n): n, for formulas written with explicit rounding
+		"min":   -1, // min(a, b, ...): smallest argument
+		"max":   -1, // max(a, b, ...): largest argument
+	},
+}
+
+// countExpressionFuncs implements CountExpressionSymbols.Funcs
+var countExpressionFuncs = map[string]expr.Func{
+	"floor": func(args []expr.Value) (expr.Value, error) {
+		if _, ok := args[0].(int64); !ok {
+			return nil, errors.New("argument is not a number")
+		}
+		return args[0], nil
+	},
+	"min": func(args []expr.Value) (expr.Value, error) {
+		return countArgs(args, func(a, b int64) bool { return a < b })
+	},
+	"max": func(args []expr.Value) (expr.Value, error) {
+		return countArgs(args, func(a, b int64) bool { return a > b })
+	},
+}
+
+// countArgs returns the first number argument unless a later one is better
+func countArgs(args []expr.Value, better func(a, b int64) bool) (expr.Value, error) {
+	if len(args) == 0 {
+		return nil, errors.New("needs at least one number")
+	}
+	var best int64
+	for i, arg := range args {
+		n, ok := arg.(int64)
+		if !ok {
+			return nil, fmt.Errorf("argument %d is not a number", i+1)
+		}
+		if i == 0 || better(n, best) {
+			best = n
+		}
+	}
+	return best, nil
+}
+
+// CompileCountExpression parses and checks a count formula
+func CompileCountExpression(src string) (*expr.Program, error) {
+	return expr.Compile(src, CountExpressionSymbols)
+}
+
+// evalFormula evaluates a formula count for a player count and applies its clamps
+func (rc *RoleCount) evalFormula(playerCount int) (int, error) {
+	program := rc.program
+	if program == nil {
+		// Counts built in code rather than decoded are compiled on use
+		var err error
+		if program, err = CompileCountExpression(rc.Formula); err != nil {
+			return 0, err
+		}
+	}
+
+	count, err := program.EvalInt(&expr.Env{
+		Vars:  map[string]expr.Value{"players": int64(playerCount)},
+		Funcs: countExpressionFuncs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if rc.Min != nil {
+		count = max(count, int64(*rc.Min))
+	}
+	if rc.Max != nil {
+		count = min(count, int64(*rc.Max))
+	}
+	if count < 0 {
+		return 0, fmt.Errorf("count is %d", count)
+	}
+	return int(count), nil
+}
+
+// validateRoleCount checks a role's count for every player count the role can be dealt to
+// Ranges must not overlap or leave player counts without a count; formulas must compile
+// and give a count of at least 0.
+func validateRoleCount(role RoleDefinition) []error {
+	count := role.Count
+	var errs []error
+
+	switch {
+	case count.Fixed != nil:
+		if *count.Fixed < 0 {
+			errs = append(errs, fmt.Errorf("count must be >= 0 for role '%s'", role.ID))
+		}
+
+	case count.Formula != "":
+		if count.Min != nil && count.Max != nil && *count.Min > *count.Max {
+			errs = append(errs, fmt.Errorf("count min %d is above max %d for role '%s'", *count.Min, *count.Max, role.ID))
+			break
+		}
+		if _, err := CompileCountExpression(count.Formula); err != nil {
+			errs = append(errs, fmt.Errorf("invalid count formula for role '%s': %w", role.ID, err))
+			break
+		}
+		for players := max(role.MinPlayers, 6); players <= maxPlayerCount; players++ {
+			if _, err := count.evalFormula(players); err != nil {
+				errs = append(errs, fmt.Errorf("count formula for role '%s' fails for %d players: %w", role.ID, players, err))
+				break
+			}
+		}
+
+	case count.Ranges != nil:
+		for key, n := range count.Ranges {
+			if _, _, ok := parseCountRange(key); !ok {
+				errs = append(errs, fmt.Errorf("invalid count range '%s' for role '%s'", key, role.ID))
+			} else if n < 0 {
+				errs = append(errs, fmt.Errorf("count for '%s' must be >= 0 for role '%s'", key, role.ID))
+			}
+		}
+		if len(errs) > 0 {
+			break
+		}
+
+		// Ranges are sorted by their first player count, so neighbours are compared
+		ranges := sortedCountRanges(count.Ranges)
+		covered := max(role.MinPlayers, 6) - 1 // Highest player count with a count so far
+		for i, r := range ranges {
+			if i > 0 && r.min <= ranges[i-1].max {
+				errs = append(errs, fmt.Errorf("count ranges '%s' and '%s' overlap for role '%s'", ranges[i-1].key, r.key, role.ID))
+			}
+			if r.min > covered+1 && covered+1 <= maxPlayerCount {
+				errs = append(errs, fmt.Errorf("count ranges give no count for %s players for role '%s'", playerSpan(covered+1, min(r.min-1, maxPlayerCount)), role.ID))
+			}
+			covered = max(covered, min(r.max, maxPlayerCount))
+		}
+		if covered < maxPlayerCount {
+			errs = append(errs, fmt.Errorf("count ranges give no count for %s players for role '%s'", playerSpan(covered+1, maxPlayerCount), role.ID))
+		}
+	}
+
+	return errs
+}
+
+// playerSpan describes a range of player counts ("7" or "10-14")
+func playerSpan(from, to int) string {
+	if from == to {
+		return fmt.Sprintf("%d", from)
+	}
+	return fmt.Sprintf("%d-%d", from, to)
+}
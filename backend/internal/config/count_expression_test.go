@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRoleCount_GetCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		count string
+		want  map[int]int // player count -> expected count
+	}{
+		{name: "fixed", count: `2`, want: map[int]int{6: 2, 30: 2}},
+		{name: "ranges", count: `{"6-9": 1, "10-14": 2, "15+": 3}`, want: map[int]int{6: 1, 9: 1, 10: 2, 14: 2, 15: 3, 30: 3}},
+		{name: "exact beats range", count: `{"6-9": 1, "8": 5, "10+": 2}`, want: map[int]int{7: 1, 8: 5, 9: 1}},
+		{name: "overlap resolves to the lowest range", count: `{"6-12": 1, "10+": 2}`, want: map[int]int{10: 1, 13: 2}},
+		{name: "formula", count: `"floor(players/5)"`, want: map[int]int{6: 1, 9: 1, 10: 2, 30: 6}},
+		{name: "rounding up", count: `"(players + 4) / 5"`, want: map[int]int{6: 2, 10: 2, 11: 3}},
+		{name: "formula with min and max", count: `"max(1, min(players/6, 3))"`, want: map[int]int{6: 1, 12: 2, 30: 3}},
+		{name: "clamped formula", count: `{"formula": "players/4 - 1", "min": 1, "max": 4}`, want: map[int]int{6: 1, 12: 2, 30: 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var count RoleCount
+			if err := json.Unmarshal([]byte(tt.count), &count); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			for players, want := range tt.want {
+				if got := count.GetCount(players); got != want {
+					t.Errorf("Expected %d for %d players, got %d", want, players, got)
+				}
+			}
+
+			// The count is written back in the form it was read
+			data, err := json.Marshal(count)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var roundTrip RoleCount
+			if err := json.Unmarshal(data, &roundTrip); err != nil {
+				t.Fatalf("Unmarshal of %s failed: %v", data, err)
+			}
+			for players, want := range tt.want {
+				if got := roundTrip.GetCount(players); got != want {
+					t.Errorf("Expected %d for %d players after %s, got %d", want, players, data, got)
+				}
+			}
+		})
+	}
+}
+
+func TestRoleConfigLoader_CountValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   string
+		wantErr string
+	}{
+		{name: "overlapping ranges", count: `{"6-12": 1, "10+": 2}`, wantErr: "count ranges '6-12' and '10+' overlap"},
+		{name: "gap between ranges", count: `{"6-9": 1, "15+": 2}`, wantErr: "count ranges give no count for 10-14 players"},
+		{name: "ranges end early", count: `{"6-9": 1, "10-20": 2}`, wantErr: "count ranges give no count for 21-30 players"},
+		{name: "ranges start late", count: `{"7+": 1}`, wantErr: "count ranges give no count for 6 players"},
+		{name: "invalid range", count: `{"6-x": 1, "7+": 1}`, wantErr: "invalid count range '6-x'"},
+		{name: "negative range count", count: `{"6+": -1}`, wantErr: "count for '6+' must be >= 0"},
+		{name: "unknown formula variable", count: `"rooms / 2"`, wantErr: `invalid count formula for role 'DRUNK': unknown variable "rooms"`},
+		{name: "formula syntax", count: `"players /"`, wantErr: "invalid count formula"},
+		{name: "negative formula", count: `"players - 10"`, wantErr: "fails for 6 players: count is -4"},
+		{name: "division by zero", count: `"6 / (players - 6)"`, wantErr: "fails for 6 players: division by zero"},
+		{name: "boolean formula", count: `"players > 10"`, wantErr: "must evaluate to a number"},
+		{name: "min above max", count: `{"formula": "players/5", "min": 3, "max": 2}`, wantErr: "count min 3 is above max 2"},
+		{name: "unknown formula field", count: `{"formula": "players/5", "minimum": 1}`, wantErr: "a formula object has only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(fmt.Sprintf(buryTestConfig, BuryNever), `"type": "grey", "count": 1`, `"type": "grey", "count": `+tt.count, 1)
+			_, _, err := loadTestConfig(t, "bury-test.json", data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -1,8 +1,16 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/expr"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 )
 
 // RoleType represents the category of a role
@@ -20,49 +28,194 @@ const (
 type TeamColor string
 
 const (
-	TeamRed  TeamColor = "RED"
-	TeamBlue TeamColor = "BLUE"
-	TeamGrey TeamColor = "GREY" // Neutral/Independent
+	TeamRed    TeamColor = "RED"
+	TeamBlue   TeamColor = "BLUE"
+	TeamGrey   TeamColor = "GREY"   // Neutral/Independent
+	TeamZombie TeamColor = "ZOMBIE" // Contagious: spreads to anyone who shares with a zombie
+)
+
+// GoalBinding describes how a role's goal is bound to its first card or color share partner
+type GoalBinding string
+
+const (
+	GoalBindingOutcome  GoalBinding = "outcome"  // Wins if the partner wins (Clone)
+	GoalBindingGoal     GoalBinding = "goal"     // Adopts the partner's win condition
+	GoalBindingOpposite GoalBinding = "opposite" // Wins if the partner loses (Robot)
+)
+
+// PowerType identifies an activated role power
+type PowerType string
+
+const (
+	PowerCupid    PowerType = "CUPID"        // Once per game: two players gain "in love"
+	PowerEris     PowerType = "ERIS"         // Once per game: two players gain "in hate"
+	PowerBoom     PowerType = "BOOM"         // Card share with the President: own room gains "dead" and the game ends
+	PowerAgent    PowerType = "AGENT"        // Once per round: force one player to card share with you
+	PowerEnforcer PowerType = "ENFORCER"     // Once per round: force two other players to card share with each other
+	PowerPsych    PowerType = "PSYCHOLOGIST" // Card share with a player to cure their psych conditions
+)
+
+// ConditionType identifies a condition a role can start the game with
+type ConditionType string
+
+const (
+	ConditionShy      ConditionType = "SHY"      // Cannot share at all
+	ConditionCoy      ConditionType = "COY"      // May only color share
+	ConditionSavvy    ConditionType = "SAVVY"    // May only card share
+	ConditionParanoid ConditionType = "PARANOID" // May card share only once per game
+	ConditionFoolish  ConditionType = "FOOLISH"  // Cannot refuse a share
+	ConditionCursed   ConditionType = "CURSED"   // May only speak in "ahh" sounds
+)
+
+// CardShareEffect describes how a role changes the conditions of anyone who card shares with it
+type CardShareEffect struct {
+	GiveConditions  []ConditionType `json:"giveConditions,omitempty"`  // Conditions the partner gains
+	ClearConditions bool            `json:"clearConditions,omitempty"` // Partner loses every condition (Medic)
+	SwapCards       bool            `json:"swapCards,omitempty"`       // Holder and partner exchange cards on any share (Hot Potato, Leprechaun)
+}
+
+// FinalActionType identifies a choice a role makes after round 3, before the reveal
+type FinalActionType string
+
+const (
+	FinalActionShoot   FinalActionType = "SHOOT"   // Choose a player to shoot (Sniper)
+	FinalActionPredict FinalActionType = "PREDICT" // Predict the winning team (Gambler)
+)
+
+// WinCondition declares a Grey role's goal as its final room relative to other roles,
+// an expression over the end-of-game state, or both. Every listed condition must hold.
+type WinCondition struct {
+	SameRoomAs        []string `json:"sameRoomAs,omitempty"`        // End in the same room as each of these roles
+	DifferentRoomFrom []string `json:"differentRoomFrom,omitempty"` // End in a different room from each of these roles
+	ExclusiveWith     []string `json:"exclusiveWith,omitempty"`     // Lose if any of these roles also meets its own room goal
+	Expression        string   `json:"expression,omitempty"`        // Expression that must be true (see WinExpressionSymbols)
+}
+
+// BuryMode controls whether one extra card is dealt face down and set aside
+type BuryMode string
+
+const (
+	BuryNever  BuryMode = "never"  // Every dealt card goes to a player (default)
+	BuryOdd    BuryMode = "odd"    // Bury a card when the player count is odd (official rule)
+	BuryAlways BuryMode = "always" // Always bury a card
 )
 
 // RoleConfig represents the root configuration structure
+// A configuration can extend another one: it starts from the other configuration's roles,
+// keeps or drops them with Include and Exclude, changes single fields with Overrides and
+// adds (or fully replaces) roles with Roles. The loader resolves this, so loaded
+// configurations always list every role.
 type RoleConfig struct {
-	ID            string           `json:"id"`
-	Name          string           `json:"name"`
-	NameKo        string           `json:"nameKo"`
-	Description   string           `json:"description"`
-	DescriptionKo string           `json:"descriptionKo"`
-	Version       string           `json:"version"`
-	Roles         []RoleDefinition `json:"roles"`
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	NameKo        string            `json:"nameKo"`
+	Description   string            `json:"description"`
+	DescriptionKo string            `json:"descriptionKo"`
+	Version       string            `json:"version"`
+	Revision      int               `json:"revision,omitempty"`  // Assigned by the loader; bumped on every save
+	Bury          BuryMode          `json:"bury,omitempty"`      // Set aside one extra hidden card, revealed at the end; inherited if unset
+	Extends       string            `json:"extends,omitempty"`   // ID of the configuration whose roles this one starts from
+	Include       []string          `json:"include,omitempty"`   // Roles kept from the extended configuration (all if empty)
+	Exclude       []string          `json:"exclude,omitempty"`   // Roles dropped from the extended configuration
+	Overrides     []json.RawMessage `json:"overrides,omitempty"` // Partial role definitions, matched by "id", merged onto extended roles
+	Roles         []RoleDefinition  `json:"roles"`
 }
 
 // RoleDefinition defines a single role in the game
 type RoleDefinition struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	NameKo        string    `json:"nameKo"`
-	Team          TeamColor `json:"team"`
-	Type          RoleType  `json:"type"`
-	Description   string    `json:"description"`
-	DescriptionKo string    `json:"descriptionKo"`
-	Count         RoleCount `json:"count"`
-	MinPlayers    int       `json:"minPlayers"`
-	Priority      int       `json:"priority"`
-	Color         string    `json:"color,omitempty"`
-	Icon          string    `json:"icon,omitempty"`
-}
-
-// RoleCount can be a fixed number or a map of player ranges
+	ID              string           `json:"id"`
+	Name            string           `json:"name"`
+	NameKo          string           `json:"nameKo"`
+	Team            TeamColor        `json:"team"`
+	Type            RoleType         `json:"type"`
+	Description     string           `json:"description"`
+	DescriptionKo   string           `json:"descriptionKo"`
+	Names           i18n.Text        `json:"names,omitempty"`        // Name by locale; name and nameKo fill in "en" and "ko" if missing
+	Descriptions    i18n.Text        `json:"descriptions,omitempty"` // Description by locale; description and descriptionKo fill in "en" and "ko" if missing
+	Count           RoleCount        `json:"count"`
+	MinPlayers      int              `json:"minPlayers"`
+	Priority        int              `json:"priority"`
+	Color           string           `json:"color,omitempty"`
+	Icon            string           `json:"icon,omitempty"`
+	RequiresRoles   []string         `json:"requiresRoles,omitempty"`   // Paired roles that must be dealt together (e.g. ROMEO -> JULIET)
+	GoalBinding     GoalBinding      `json:"goalBinding,omitempty"`     // Goal bound to the first card or color share partner (e.g. CLONE, ROBOT)
+	Power           PowerType        `json:"power,omitempty"`           // Activated power (e.g. CUPID, ERIS)
+	Conditions      []ConditionType  `json:"conditions,omitempty"`      // Conditions the role starts the game with (e.g. SHY)
+	OnCardShare     *CardShareEffect `json:"onCardShare,omitempty"`     // Effect applied to card share partners (e.g. DEALER, MEDIC)
+	FinalAction     FinalActionType  `json:"finalAction,omitempty"`     // End-of-game choice (e.g. SNIPER shoots, GAMBLER predicts)
+	BackupFor       string           `json:"backupFor,omitempty"`       // Role this role stands in for if its card is buried (e.g. MARTYR -> BOMBER)
+	SwapBuriedRound int              `json:"swapBuriedRound,omitempty"` // Round at whose start the holder swaps cards with the buried card (e.g. DRUNK)
+	WinCondition    *WinCondition    `json:"winCondition,omitempty"`    // Declarative room goal for Grey roles (e.g. BUTLER, WIFE)
+}
+
+// NameText returns the role's name in every locale it is written in
+func (r *RoleDefinition) NameText() i18n.Text {
+	return r.Names.With(i18n.English, r.Name).With(i18n.Korean, r.NameKo)
+}
+
+// DescriptionText returns the role's description in every locale it is written in
+func (r *RoleDefinition) DescriptionText() i18n.Text {
+	return r.Descriptions.With(i18n.English, r.Description).With(i18n.Korean, r.DescriptionKo)
+}
+
+// FindRole returns the role definition with the given ID, or nil if not defined
+func (rc *RoleConfig) FindRole(id string) *RoleDefinition {
+	for i := range rc.Roles {
+		if rc.Roles[i].ID == id {
+			return &rc.Roles[i]
+		}
+	}
+	return nil
+}
+
+// ShouldBury reports whether a card is buried for the given player count and role selection
+// A role that swaps with the buried card (Drunk) always needs one, whatever the bury setting.
+func (rc *RoleConfig) ShouldBury(playerCount int, roles []RoleDefinition) bool {
+	for _, role := range roles {
+		if role.SwapBuriedRound > 0 {
+			return true
+		}
+	}
+	switch rc.Bury {
+	case BuryAlways:
+		return true
+	case BuryOdd:
+		return playerCount%2 == 1
+	default:
+		return false
+	}
+}
+
+// maxPlayerCount is the largest supported room; counts are checked up to it
+const maxPlayerCount = 30
+
+// RoleCount can be a fixed number, a map of player ranges or a formula of the player count
 type RoleCount struct {
-	Fixed  *int           `json:"-"`
-	Ranges map[string]int `json:"-"`
+	Fixed   *int           `json:"-"`
+	Ranges  map[string]int `json:"-"`
+	Formula string         `json:"-"` // e.g. "players/5"; see CountExpressionSymbols
+	Min     *int           `json:"-"` // Clamps applied to the formula's result
+	Max     *int           `json:"-"`
+
+	program *expr.Program // Compiled Formula; nil until decoded or if it does not compile
 }
 
-// UnmarshalJSON handles both int and object formats for RoleCount
+// roleCountFormula is the object form of a formula count
+type roleCountFormula struct {
+	Formula string `json:"formula"`
+	Min     *int   `json:"min,omitempty"`
+	Max     *int   `json:"max,omitempty"`
+}
+
+// UnmarshalJSON handles the int, object and formula formats for RoleCount
 // Examples:
 //   - Fixed count: "count": 1
 //   - Range-based: "count": {"6-9": 1, "10+": 2}
+//   - Formula: "count": "floor(players/5)"
+//   - Clamped formula: "count": {"formula": "players/5", "min": 1, "max": 4}
 func (rc *RoleCount) UnmarshalJSON(data []byte) error {
+	*rc = RoleCount{} // An override replaces the whole count
+
 	// Try to unmarshal as int first (fixed count)
 	var fixed int
 	if err := json.Unmarshal(data, &fixed); err == nil {
@@ -70,21 +223,55 @@ func (rc *RoleCount) UnmarshalJSON(data []byte) error {
 		return nil
 	}
 
+	// A string is a formula
+	var formula string
+	if err := json.Unmarshal(data, &formula); err == nil {
+		rc.setFormula(roleCountFormula{Formula: formula})
+		return nil
+	}
+
+	// An object with a "formula" key is a clamped formula
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil {
+		if _, ok := fields["formula"]; ok {
+			var clamped roleCountFormula
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&clamped); err != nil {
+				return fmt.Errorf("invalid count format: a formula object has only \"formula\", \"min\" and \"max\"")
+			}
+			rc.setFormula(clamped)
+			return nil
+		}
+	}
+
 	// Try to unmarshal as object (range-based count)
 	var ranges map[string]int
 	if err := json.Unmarshal(data, &ranges); err != nil {
-		return fmt.Errorf("invalid count format: must be int or map[string]int")
+		return fmt.Errorf("invalid count format: must be int, formula or map[string]int")
 	}
 
 	rc.Ranges = ranges
 	return nil
 }
 
+// setFormula sets a formula count; a formula that does not compile is reported by validation
+func (rc *RoleCount) setFormula(formula roleCountFormula) {
+	rc.Formula, rc.Min, rc.Max = formula.Formula, formula.Min, formula.Max
+	rc.program, _ = CompileCountExpression(formula.Formula)
+}
+
 // MarshalJSON converts RoleCount back to JSON
 func (rc RoleCount) MarshalJSON() ([]byte, error) {
 	if rc.Fixed != nil {
 		return json.Marshal(*rc.Fixed)
 	}
+	if rc.Formula != "" {
+		if rc.Min == nil && rc.Max == nil {
+			return json.Marshal(rc.Formula)
+		}
+		return json.Marshal(roleCountFormula{Formula: rc.Formula, Min: rc.Min, Max: rc.Max})
+	}
 	if rc.Ranges != nil {
 		return json.Marshal(rc.Ranges)
 	}
@@ -98,17 +285,27 @@ func (rc *RoleCount) GetCount(playerCount int) int {
 		return *rc.Fixed
 	}
 
+	// If a formula, evaluate it; validation makes sure it gives a count for every player count
+	if rc.Formula != "" {
+		count, err := rc.evalFormula(playerCount)
+		if err != nil {
+			return 0
+		}
+		return count
+	}
+
 	// If range-based, find matching range
 	if rc.Ranges != nil {
 		// Check for exact match first
-		if count, ok := rc.Ranges[fmt.Sprintf("%d", playerCount)]; ok {
+		if count, ok := rc.Ranges[strconv.Itoa(playerCount)]; ok {
 			return count
 		}
 
-		// Check for range patterns (e.g., "6-9", "10+")
-		for rangeKey, count := range rc.Ranges {
-			if matchesRange(playerCount, rangeKey) {
-				return count
+		// Check range patterns (e.g., "6-9", "10+") from the lowest, so overlaps always
+		// resolve the same way (validation rejects them)
+		for _, r := range sortedCountRanges(rc.Ranges) {
+			if playerCount >= r.min && playerCount <= r.max {
+				return r.count
 			}
 		}
 	}
@@ -116,31 +313,54 @@ func (rc *RoleCount) GetCount(playerCount int) int {
 	return 0
 }
 
-// matchesRange checks if a player count matches a range pattern
+// countRange is a parsed player range of a range-based count
+type countRange struct {
+	key      string
+	min, max int
+	count    int
+}
+
+// parseCountRange parses a player range pattern
 // Supported patterns:
 //   - "6-9": players between 6 and 9 (inclusive)
 //   - "10+": players 10 or more
 //   - "6": exactly 6 players
-func matchesRange(playerCount int, rangePattern string) bool {
+func parseCountRange(rangePattern string) (min, max int, ok bool) {
+	pattern := strings.TrimSpace(rangePattern)
+
 	// Check for "N+" pattern (e.g., "10+")
-	var minPlus int
-	if n, err := fmt.Sscanf(rangePattern, "%d+", &minPlus); n == 1 && err == nil {
-		return playerCount >= minPlus
+	if prefix, found := strings.CutSuffix(pattern, "+"); found {
+		n, err := strconv.Atoi(prefix)
+		return n, math.MaxInt, err == nil
 	}
 
 	// Check for "N-M" pattern (e.g., "6-9")
-	var min, max int
-	if n, err := fmt.Sscanf(rangePattern, "%d-%d", &min, &max); n == 2 && err == nil {
-		return playerCount >= min && playerCount <= max
+	if from, to, found := strings.Cut(pattern, "-"); found {
+		lo, errLo := strconv.Atoi(from)
+		hi, errHi := strconv.Atoi(to)
+		return lo, hi, errLo == nil && errHi == nil && lo <= hi
 	}
 
 	// Check for exact match "N" (e.g., "6")
-	var exact int
-	if n, err := fmt.Sscanf(rangePattern, "%d", &exact); n == 1 && err == nil {
-		return playerCount == exact
-	}
+	n, err := strconv.Atoi(pattern)
+	return n, n, err == nil
+}
 
-	return false
+// sortedCountRanges parses a range-based count's ranges, lowest first; invalid patterns are skipped
+func sortedCountRanges(ranges map[string]int) []countRange {
+	parsed := make([]countRange, 0, len(ranges))
+	for key, count := range ranges {
+		if lo, hi, ok := parseCountRange(key); ok {
+			parsed = append(parsed, countRange{key: key, min: lo, max: hi, count: count})
+		}
+	}
+	sort.Slice(parsed, func(i, j int) bool {
+		if parsed[i].min != parsed[j].min {
+			return parsed[i].min < parsed[j].min
+		}
+		return parsed[i].key < parsed[j].key
+	})
+	return parsed
 }
 
 // RoleConfigMeta contains metadata about a role configuration
@@ -152,6 +372,7 @@ type RoleConfigMeta struct {
 	Description   string `json:"description"`
 	DescriptionKo string `json:"descriptionKo"`
 	Version       string `json:"version"`
+	Revision      int    `json:"revision"`
 }
 
 // ToMeta converts a RoleConfig to RoleConfigMeta
@@ -163,5 +384,6 @@ func (rc *RoleConfig) ToMeta() RoleConfigMeta {
 		Description:   rc.Description,
 		DescriptionKo: rc.DescriptionKo,
 		Version:       rc.Version,
+		Revision:      rc.Revision,
 	}
 }
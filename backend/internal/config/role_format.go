@@ -0,0 +1,276 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// configFormat is the file format a configuration is written in, chosen by file extension
+type configFormat string
+
+const (
+	formatJSON configFormat = "JSON"
+	formatYAML configFormat = "YAML"
+	formatTOML configFormat = "TOML"
+)
+
+// configFormats maps the file extensions the loader reads to their format
+var configFormats = map[string]configFormat{
+	".json": formatJSON,
+	".yaml": formatYAML,
+	".yml":  formatYAML,
+	".toml": formatTOML,
+}
+
+// formatOf returns the format of a configuration file
+func formatOf(path string) configFormat {
+	if format, ok := configFormats[strings.ToLower(filepath.Ext(path))]; ok {
+		return format
+	}
+	return formatJSON
+}
+
+// configFiles lists the configuration files in a directory, sorted
+func configFiles(dir string) ([]string, error) {
+	var files []string
+	for ext := range configFormats {
+		matches, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// decodeRoleConfig decodes a configuration file in any supported format
+// YAML and TOML decode into the same fields as JSON, including the int-or-ranges count.
+func decodeRoleConfig(path string, data []byte) (*RoleConfig, error) {
+	var config RoleConfig
+	switch formatOf(path) {
+	case formatYAML:
+		if err := yaml.UnmarshalWithOptions(data, &config, yaml.UseJSONUnmarshaler()); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %s", yaml.FormatError(err, false, false))
+		}
+	case formatTOML:
+		// TOML has no JSON unmarshalers, so the document goes through JSON
+		var document map[string]any
+		if err := toml.Unmarshal(data, &document); err != nil {
+			var decodeErr *toml.DecodeError
+			if errors.As(err, &decodeErr) {
+				line, column := decodeErr.Position()
+				return nil, fmt.Errorf("invalid TOML: line %d, column %d: %w", line, column, err)
+			}
+			return nil, fmt.Errorf("invalid TOML: %w", err)
+		}
+		converted, err := json.Marshal(document)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOML: %w", err)
+		}
+		if err := json.Unmarshal(converted, &config); err != nil {
+			return nil, fmt.Errorf("invalid TOML: %w", err)
+		}
+	default:
+		if err := json.Unmarshal(data, &config); err != nil {
+			if line := jsonErrorLine(data, err); line > 0 {
+				return nil, fmt.Errorf("invalid JSON: line %d: %w", line, err)
+			}
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	return &config, nil
+}
+
+// encodeRoleConfig encodes a configuration in the format of the file it is saved to
+func encodeRoleConfig(path string, config *RoleConfig) ([]byte, error) {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	switch formatOf(path) {
+	case formatYAML:
+		return yaml.JSONToYAML(data)
+	case formatTOML:
+		// Decode numbers as integers where possible, so counts are not written as floats
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var document map[string]any
+		if err := decoder.Decode(&document); err != nil {
+			return nil, err
+		}
+		return toml.Marshal(tomlNumbers(document))
+	default:
+		return append(data, '\n'), nil
+	}
+}
+
+// tomlNumbers replaces the JSON numbers in a decoded document with integers or floats
+func tomlNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = tomlNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = tomlNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// jsonErrorLine returns the line a JSON decoding error occurred on, or 0 if unknown
+func jsonErrorLine(data []byte, err error) int {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return 0
+	}
+	offset = min(offset, int64(len(data)))
+	return strings.Count(string(data[:offset]), "\n") + 1
+}
+
+// fieldLines finds the line a field path such as "roles[3].minPlayers" is written on,
+// falling back to the closest enclosing field; 0 if unknown
+type fieldLines func(path string) int
+
+// readFieldLines indexes the field positions of a configuration file
+func readFieldLines(path string) fieldLines {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return func(string) int { return 0 }
+	}
+	if formatOf(path) == formatTOML {
+		return tomlFieldLines(data)
+	}
+	return yamlFieldLines(data) // JSON is read as YAML, which keeps positions
+}
+
+// yamlFieldLines looks fields up in a parsed YAML (or JSON) document
+func yamlFieldLines(data []byte) fieldLines {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return func(string) int { return 0 }
+	}
+	return func(path string) int {
+		for ; path != ""; path = parentPath(path) {
+			yamlPath, err := yaml.PathString("$." + path)
+			if err != nil {
+				continue
+			}
+			if node, err := yamlPath.FilterFile(file); err == nil && node != nil {
+				return node.GetToken().Position.Line
+			}
+		}
+		return 0
+	}
+}
+
+// tomlFieldLines looks fields up in a TOML document by its keys and table headers
+// Arrays of tables ([[roles]]) are numbered in order, so "roles[3]" is the fourth [[roles]].
+func tomlFieldLines(data []byte) fieldLines {
+	lines := make(map[string]int)
+	arrayIndex := make(map[string]int) // array table key -> index of its current table
+
+	var p unstable.Parser
+	p.Reset(data)
+	table := ""
+	for p.NextExpression() {
+		expression := p.Expression()
+		switch expression.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			keys := tomlKeys(expression.Key())
+			if expression.Kind == unstable.ArrayTable {
+				key := strings.Join(keys, ".")
+				if _, ok := arrayIndex[key]; ok {
+					arrayIndex[key]++
+				} else {
+					arrayIndex[key] = 0
+				}
+			}
+			table = tomlPath(keys, arrayIndex)
+			lines[table] = tomlKeyLine(&p, expression)
+		case unstable.KeyValue:
+			path := strings.Join(tomlKeys(expression.Key()), ".")
+			if table != "" {
+				path = table + "." + path
+			}
+			lines[path] = tomlKeyLine(&p, expression)
+		}
+	}
+
+	return func(path string) int {
+		for ; path != ""; path = parentPath(path) {
+			if line, ok := lines[path]; ok {
+				return line
+			}
+		}
+		return 0
+	}
+}
+
+// tomlKeys returns the parts of a dotted TOML key
+func tomlKeys(it unstable.Iterator) []string {
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Node().Data))
+	}
+	return keys
+}
+
+// tomlPath turns table keys into a field path, numbering arrays of tables
+func tomlPath(keys []string, arrayIndex map[string]int) string {
+	var path strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			path.WriteString(".")
+		}
+		path.WriteString(key)
+		if index, ok := arrayIndex[strings.Join(keys[:i+1], ".")]; ok {
+			fmt.Fprintf(&path, "[%d]", index)
+		}
+	}
+	return path.String()
+}
+
+// tomlKeyLine returns the line an expression's key starts on
+func tomlKeyLine(p *unstable.Parser, expression *unstable.Node) int {
+	it := expression.Key()
+	if !it.Next() {
+		return 0
+	}
+	return p.Shape(it.Node().Raw).Start.Line
+}
+
+// parentPath removes the last field or index from a path ("roles[3].count" -> "roles[3]" -> "roles")
+func parentPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
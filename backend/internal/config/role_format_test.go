@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buryTestConfig is a minimal role configuration with the given bury setting
+const buryTestConfig = `{
+  "id": "bury-test",
+  "name": "Bury Test",
+  "version": "1.0.0",
+  "bury": %q,
+  "roles": [
+    {"id": "PRESIDENT", "name": "President", "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1},
+    {"id": "BLUE_TEAM", "name": "Blue Team", "team": "BLUE", "type": "operative", "count": 99, "minPlayers": 6, "priority": 99},
+    {"id": "BOMBER", "name": "Bomber", "team": "RED", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1},
+    {"id": "RED_TEAM", "name": "Red Team", "team": "RED", "type": "operative", "count": 99, "minPlayers": 6, "priority": 99},
+    {"id": "DRUNK", "name": "Drunk", "team": "GREY", "type": "grey", "count": 1, "minPlayers": 6, "priority": 3, "swapBuriedRound": 3}
+  ]
+}`
+
+// extendingTestConfig extends the bury test configuration with an override
+const extendingTestConfig = `{
+  "id": "house-rules",
+  "name": "House Rules",
+  "version": "1.0.0",
+  "extends": "bury-test",
+  "exclude": ["DRUNK"],
+  "overrides": [{"id": "PRESIDENT", "minPlayers": 7}],
+  "roles": [
+    {"id": "GAMBLER", "name": "Gambler", "team": "GREY", "type": "grey", "count": 1, "minPlayers": 10, "priority": 3, "finalAction": "PREDICT"}
+  ]
+}`
+
+// yamlTestConfig is the bury test configuration in YAML, with a ranged Drunk count
+const yamlTestConfig = `id: bury-test
+name: Bury Test
+version: 1.0.0
+bury: odd
+roles:
+  - {id: PRESIDENT, name: President, team: BLUE, type: leader, count: 1, minPlayers: 6, priority: 1}
+  - {id: BLUE_TEAM, name: Blue Team, team: BLUE, type: operative, count: 99, minPlayers: 6, priority: 99}
+  - {id: BOMBER, name: Bomber, team: RED, type: leader, count: 1, minPlayers: 6, priority: 1}
+  - {id: RED_TEAM, name: Red Team, team: RED, type: operative, count: 99, minPlayers: 6, priority: 99}
+  - id: DRUNK
+    name: Drunk
+    team: GREY
+    type: grey
+    count:
+      "6-9": 1
+      "10+": 2
+    minPlayers: 6
+    priority: 3
+`
+
+// tomlTestConfig is the bury test configuration in TOML, with a ranged Drunk count
+const tomlTestConfig = `id = "bury-test"
+name = "Bury Test"
+version = "1.0.0"
+bury = "odd"
+
+[[roles]]
+id = "PRESIDENT"
+name = "President"
+team = "BLUE"
+type = "leader"
+count = 1
+minPlayers = 6
+priority = 1
+
+[[roles]]
+id = "BLUE_TEAM"
+name = "Blue Team"
+team = "BLUE"
+type = "operative"
+count = 99
+minPlayers = 6
+priority = 99
+
+[[roles]]
+id = "BOMBER"
+name = "Bomber"
+team = "RED"
+type = "leader"
+count = 1
+minPlayers = 6
+priority = 1
+
+[[roles]]
+id = "RED_TEAM"
+name = "Red Team"
+team = "RED"
+type = "operative"
+count = 99
+minPlayers = 6
+priority = 99
+
+[[roles]]
+id = "DRUNK"
+name = "Drunk"
+team = "GREY"
+type = "grey"
+minPlayers = 6
+priority = 3
+
+[roles.count]
+"6-9" = 1
+"10+" = 2
+`
+
+// loadTestConfigs writes the given files to a directory and loads it
+func loadTestConfigs(t *testing.T, files map[string]string) (*RoleConfigLoader, error) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	loader := NewRoleConfigLoader(dir)
+	return loader, loader.LoadAll()
+}
+
+// loadTestConfig loads a single configuration file written under the given name
+func loadTestConfig(t *testing.T, name, data string) (*RoleConfigLoader, string, error) {
+	t.Helper()
+	loader, err := loadTestConfigs(t, map[string]string{name: data})
+	return loader, filepath.Join(loader.Dir(), name), err
+}
+
+// roleIDs lists a configuration's role IDs in order
+func roleIDs(roleConfig *RoleConfig) string {
+	var ids []string
+	for _, role := range roleConfig.Roles {
+		ids = append(ids, role.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestRoleConfigLoader_Formats(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+	}{
+		{name: "yaml", file: "bury-test.yaml", data: yamlTestConfig},
+		{name: "yml", file: "bury-test.yml", data: yamlTestConfig},
+		{name: "toml", file: "bury-test.toml", data: tomlTestConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, _, err := loadTestConfig(t, tt.file, tt.data)
+			if err != nil {
+				t.Fatalf("LoadAll failed: %v", err)
+			}
+			roleConfig, err := loader.Get("bury-test")
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if got, want := roleIDs(roleConfig), "PRESIDENT BLUE_TEAM BOMBER RED_TEAM DRUNK"; got != want {
+				t.Errorf("Expected roles %q, got %q", want, got)
+			}
+			if roleConfig.Bury != BuryOdd {
+				t.Errorf("Expected bury=odd, got %q", roleConfig.Bury)
+			}
+			drunk := roleConfig.FindRole("DRUNK")
+			if drunk.Count.GetCount(8) != 1 || drunk.Count.GetCount(12) != 2 {
+				t.Errorf("Expected the ranged count 1 for 8 players and 2 for 12, got %d and %d", drunk.Count.GetCount(8), drunk.Count.GetCount(12))
+			}
+			if roleConfig.FindRole("PRESIDENT").Count.GetCount(8) != 1 {
+				t.Error("Expected the fixed President count 1")
+			}
+		})
+	}
+}
+
+func TestRoleConfigLoader_FormatErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "yaml validation",
+			file:    "bury-test.yaml",
+			data:    strings.Replace(yamlTestConfig, "    minPlayers: 6\n", "    minPlayers: 2\n", 1),
+			wantErr: "bury-test.yaml:17: roles[4].minPlayers: ",
+		},
+		{
+			name:    "toml validation",
+			file:    "bury-test.toml",
+			data:    strings.Replace(tomlTestConfig, "type = \"grey\"\nminPlayers = 6", "type = \"grey\"\nminPlayers = 2", 1),
+			wantErr: "bury-test.toml:47: roles[4].minPlayers: ",
+		},
+		{
+			name:    "json validation",
+			file:    "bury-test.json",
+			data:    strings.Replace(strings.Replace(buryTestConfig, "%q", `"odd"`, 1), `"type": "grey", "count": 1, "minPlayers": 6`, `"type": "grey", "count": 1, "minPlayers": 2`, 1),
+			wantErr: "bury-test.json:11: roles[4].minPlayers: ",
+		},
+		{
+			name:    "config field",
+			file:    "bury-test.yaml",
+			data:    strings.Replace(yamlTestConfig, "bury: odd", "bury: sometimes", 1),
+			wantErr: "bury-test.yaml:4: bury: ",
+		},
+		{
+			name:    "yaml syntax",
+			file:    "bury-test.yaml",
+			data:    strings.Replace(yamlTestConfig, "priority: 3", "priority: [3", 1),
+			wantErr: "invalid YAML: [",
+		},
+		{
+			name:    "toml syntax",
+			file:    "bury-test.toml",
+			data:    strings.Replace(tomlTestConfig, "priority = 3", "priority = ", 1),
+			wantErr: "invalid TOML: line 48",
+		},
+		{
+			name:    "yaml count type",
+			file:    "bury-test.yaml",
+			data:    strings.Replace(yamlTestConfig, `"10+": 2`, `"10+": two`, 1),
+			wantErr: "invalid count format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := loadTestConfig(t, tt.file, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRoleConfigLoader_ExtendsFieldErrorLocation(t *testing.T) {
+	overriding := strings.Replace(extendingTestConfig, `"minPlayers": 7`, `"minPlayers": 2`, 1)
+	_, err := loadTestConfigs(t, map[string]string{
+		"bury-test.json":   fmt.Sprintf(buryTestConfig, BuryOdd),
+		"house-rules.json": overriding,
+	})
+	if err == nil || !strings.Contains(err.Error(), "house-rules.json:7: overrides[0].minPlayers: ") {
+		t.Errorf("Expected the error at the override, got %v", err)
+	}
+}
+
+func TestRoleConfigLoader_UpdateKeepsFormat(t *testing.T) {
+	for _, file := range []string{"bury-test.yaml", "bury-test.toml"} {
+		t.Run(filepath.Ext(file), func(t *testing.T) {
+			data := yamlTestConfig
+			if filepath.Ext(file) == ".toml" {
+				data = tomlTestConfig
+			}
+			loader, path, err := loadTestConfig(t, file, data)
+			if err != nil {
+				t.Fatalf("LoadAll failed: %v", err)
+			}
+
+			roleConfig, _ := loader.Get("bury-test")
+			updated := *roleConfig
+			updated.Name = "Bury Test Updated"
+			if err := loader.Update("bury-test", &updated); err != nil {
+				t.Fatalf("Update failed: %v", err)
+			}
+
+			// Reloading the written file gives back the update, ranged counts included
+			written, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read %s: %v", file, err)
+			}
+			if strings.HasPrefix(strings.TrimSpace(string(written)), "{") {
+				t.Errorf("Expected %s to be written in its own format, got JSON", file)
+			}
+			reloaded, _, err := loadTestConfig(t, file, string(written))
+			if err != nil {
+				t.Fatalf("Reloading the written file failed: %v\n%s", err, written)
+			}
+			result, _ := reloaded.Get("bury-test")
+			if result.Name != "Bury Test Updated" || result.FindRole("DRUNK").Count.GetCount(12) != 2 {
+				t.Errorf("Expected the update to round-trip, got %+v", result)
+			}
+		})
+	}
+}
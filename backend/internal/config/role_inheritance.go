@@ -0,0 +1,178 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errExtendsCycle is returned when configurations extend each other in a loop
+var errExtendsCycle = errors.New("extends cycle")
+
+// configResolver expands the extends chains of a set of configurations
+// Each configuration is resolved once; results and errors are kept for configurations
+// extending it.
+type configResolver struct {
+	sources  map[string]*RoleConfig // id -> configuration as written
+	resolved map[string]*RoleConfig
+	errs     map[string]error
+	path     []string // Configurations being resolved, outermost first
+}
+
+func newConfigResolver(sources map[string]*RoleConfig) *configResolver {
+	return &configResolver{
+		sources:  sources,
+		resolved: make(map[string]*RoleConfig, len(sources)),
+		errs:     make(map[string]error),
+	}
+}
+
+// resolve returns the configuration with its extended roles expanded
+func (r *configResolver) resolve(id string) (*RoleConfig, error) {
+	if config, ok := r.resolved[id]; ok {
+		return config, nil
+	}
+	if err, ok := r.errs[id]; ok {
+		return nil, err
+	}
+	source, ok := r.sources[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, id)
+	}
+	for i, pathID := range r.path {
+		if pathID == id {
+			return nil, fmt.Errorf("%w: %s -> %s", errExtendsCycle, strings.Join(r.path[i:], " -> "), id)
+		}
+	}
+
+	r.path = append(r.path, id)
+	defer func() { r.path = r.path[:len(r.path)-1] }()
+
+	var base *RoleConfig
+	if source.Extends != "" {
+		var err error
+		base, err = r.resolve(source.Extends)
+		if err != nil {
+			if !errors.Is(err, errExtendsCycle) {
+				err = fmt.Errorf("cannot extend '%s': %w", source.Extends, err)
+			}
+			r.errs[id] = err
+			return nil, err
+		}
+	}
+
+	config, err := source.expand(base)
+	if err != nil {
+		r.errs[id] = err
+		return nil, err
+	}
+	r.resolved[id] = config
+	return config, nil
+}
+
+// expand applies the configuration's include, exclude, overrides and roles to the roles of
+// base, the resolved configuration it extends (nil if it extends none)
+// The result lists every role and has no inheritance fields other than Extends.
+func (rc *RoleConfig) expand(base *RoleConfig) (*RoleConfig, error) {
+	resolved := *rc
+	resolved.Include, resolved.Exclude, resolved.Overrides = nil, nil, nil
+
+	if base == nil {
+		if len(rc.Include) > 0 || len(rc.Exclude) > 0 || len(rc.Overrides) > 0 {
+			return nil, errors.New("include, exclude and overrides need extends")
+		}
+		return &resolved, nil
+	}
+	if resolved.Bury == "" {
+		resolved.Bury = base.Bury
+	}
+
+	// Copy the base roles so overrides never change the base configuration
+	roles, err := copyRoles(base.Roles)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	keep := func(ids []string, field string) map[string]bool {
+		set := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if base.FindRole(id) == nil {
+				errs = append(errs, fmt.Errorf("%s: role '%s' is not defined in '%s'", field, id, base.ID))
+			}
+			set[id] = true
+		}
+		return set
+	}
+	include, exclude := keep(rc.Include, "include"), keep(rc.Exclude, "exclude")
+
+	filtered := roles[:0]
+	for _, role := range roles {
+		if (len(include) == 0 || include[role.ID]) && !exclude[role.ID] {
+			filtered = append(filtered, role)
+		}
+	}
+	roles = filtered
+
+	// Overrides change only the fields they list
+	for i, override := range rc.Overrides {
+		var target struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(override, &target); err != nil || target.ID == "" {
+			errs = append(errs, fmt.Errorf("overrides[%d]: an override needs the \"id\" of the role it changes", i))
+			continue
+		}
+		index := roleIndex(roles, target.ID)
+		if index < 0 {
+			errs = append(errs, fmt.Errorf("overrides[%d]: role '%s' is not in the extended roles", i, target.ID))
+			continue
+		}
+		if err := json.Unmarshal(override, &roles[index]); err != nil {
+			errs = append(errs, fmt.Errorf("overrides[%d]: %w", i, err))
+		}
+	}
+
+	// Roles defined here replace extended roles with the same ID, or are added
+	for i, role := range rc.Roles {
+		if roleIndex(rc.Roles[:i], role.ID) >= 0 {
+			errs = append(errs, fmt.Errorf("roles[%d]: duplicate role ID '%s'", i, role.ID))
+			continue
+		}
+		if index := roleIndex(roles, role.ID); index >= 0 {
+			roles[index] = role
+		} else {
+			roles = append(roles, role)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	resolved.Roles = roles
+	return &resolved, nil
+}
+
+// copyRoles deep copies role definitions, so changing a copy leaves the originals as they were
+func copyRoles(roles []RoleDefinition) ([]RoleDefinition, error) {
+	data, err := json.Marshal(roles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy roles: %w", err)
+	}
+	var copied []RoleDefinition
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("failed to copy roles: %w", err)
+	}
+	return copied, nil
+}
+
+// roleIndex returns the index of the role with the given ID, or -1
+func roleIndex(roles []RoleDefinition, id string) int {
+	for i, role := range roles {
+		if role.ID == id {
+			return i
+		}
+	}
+	return -1
+}
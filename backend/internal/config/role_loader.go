@@ -1,15 +1,63 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
+// DefaultConfigID is the configuration rooms use when none is selected
+const DefaultConfigID = "standard"
+
+// defaultDirs are where configurations are looked for when ROLE_CONFIG_DIR is unset:
+// from the repository root, then from the backend module
+var defaultDirs = []string{"./backend/config/roles", "./config/roles"}
+
+// DefaultDir returns the directory configurations are loaded from: $ROLE_CONFIG_DIR, or the
+// first default directory that exists
+func DefaultDir() string {
+	if dir := os.Getenv("ROLE_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	for _, dir := range defaultDirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return defaultDirs[0]
+}
+
+// Errors returned when looking up or changing configurations
+var (
+	ErrConfigNotFound = errors.New("configuration not found")
+	ErrConfigExists   = errors.New("configuration already exists")
+	ErrInvalidConfig  = errors.New("invalid configuration")
+	ErrDefaultConfig  = errors.New("the default configuration cannot be deleted")
+	ErrConfigExtended = errors.New("configuration is extended by another configuration")
+)
+
+// configIDPattern restricts IDs to names that are safe to use as file names
+var configIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
 // RoleConfigLoader handles loading and caching role configurations
+// Saved configurations are never modified in place; every save stores a new revision,
+// so a room can keep using the revision it was created with.
 type RoleConfigLoader struct {
-	configs    map[string]*RoleConfig // id -> config
+	mu         sync.RWMutex
+	writeMu    sync.Mutex                     // Serializes reloads with saves and deletes; taken before mu
+	configs    map[string]*RoleConfig         // id -> latest revision, with extends resolved
+	sources    map[string]*RoleConfig         // id -> latest revision as written in its file
+	revisions  map[string]map[int]*RoleConfig // id -> revision -> config, kept after deletion
+	files      map[string]string              // id -> file the config is stored in
 	configsDir string
 }
 
@@ -17,80 +65,486 @@ type RoleConfigLoader struct {
 func NewRoleConfigLoader(configsDir string) *RoleConfigLoader {
 	return &RoleConfigLoader{
 		configs:    make(map[string]*RoleConfig),
+		sources:    make(map[string]*RoleConfig),
+		revisions:  make(map[string]map[int]*RoleConfig),
+		files:      make(map[string]string),
 		configsDir: configsDir,
 	}
 }
 
+// Dir returns the directory configurations are loaded from and saved to
+func (l *RoleConfigLoader) Dir() string {
+	return l.configsDir
+}
+
 // LoadAll loads all role configurations from the directory
+// Every file is resolved (see RoleConfig.Extends) and validated first; the loaded set is
+// replaced only if all of them are valid, otherwise the current set stays in use and
+// every error is returned.
+// Configurations whose content changed get a new revision, and earlier revisions
+// remain available to rooms created with them.
 func (l *RoleConfigLoader) LoadAll() error {
+	// A save between reading and applying would otherwise be undone by the older read
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	sources, files, err := l.readDir()
+	if err != nil {
+		return err
+	}
+
+	configs, failed := resolveSources(sources)
+	if len(failed) > 0 {
+		var errs []error
+		for _, id := range sortedIDs(failed) {
+			errs = append(errs, loadError(sources, files, id, failed[id]))
+		}
+		return errors.Join(errs...)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.apply(sources, configs, files)
+	return nil
+}
+
+// readDir reads every configuration file in the directory
+// It returns the configurations as written and the file each is stored in, keyed by ID.
+func (l *RoleConfigLoader) readDir() (map[string]*RoleConfig, map[string]string, error) {
 	// Check if directory exists
 	if _, err := os.Stat(l.configsDir); os.IsNotExist(err) {
-		return fmt.Errorf("config directory not found: %s", l.configsDir)
+		return nil, nil, fmt.Errorf("config directory not found: %s", l.configsDir)
 	}
 
-	// Read all JSON files
-	files, err := filepath.Glob(filepath.Join(l.configsDir, "*.json"))
+	// Read all JSON, YAML and TOML files
+	files, err := configFiles(l.configsDir)
 	if err != nil {
-		return fmt.Errorf("failed to list config files: %w", err)
+		return nil, nil, fmt.Errorf("failed to list config files: %w", err)
 	}
 
 	if len(files) == 0 {
-		return fmt.Errorf("no role configuration files found in %s", l.configsDir)
+		return nil, nil, fmt.Errorf("no role configuration files found in %s", l.configsDir)
 	}
 
-	// Load each file
+	// Load each file, collecting every error
+	sources := make(map[string]*RoleConfig, len(files))
+	fileByID := make(map[string]string, len(files))
+	var errs []error
 	for _, file := range files {
 		config, err := l.loadFile(file)
 		if err != nil {
-			return fmt.Errorf("failed to load %s: %w", file, err)
+			errs = append(errs, fmt.Errorf("failed to load %s: %w", file, err))
+			continue
+		}
+		if other, ok := fileByID[config.ID]; ok {
+			errs = append(errs, fmt.Errorf("failed to load %s: configuration ID '%s' is already used by %s", file, config.ID, other))
+			continue
 		}
+		fileByID[config.ID] = file
+		sources[config.ID] = config
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
 
-		// Store by ID
-		l.configs[config.ID] = config
+	return sources, fileByID, nil
+}
+
+// resolveSources resolves and validates every configuration, keyed by ID
+// Configurations that cannot be resolved or are invalid are reported in failed instead.
+func resolveSources(sources map[string]*RoleConfig) (configs map[string]*RoleConfig, failed map[string]error) {
+	resolver := newConfigResolver(sources)
+	configs = make(map[string]*RoleConfig, len(sources))
+	failed = make(map[string]error)
+	for _, id := range sortedIDs(sources) {
+		config, err := resolver.resolve(id)
+		if err == nil {
+			err = validateRoleConfig(config)
+			if err != nil {
+				err = fmt.Errorf("validation failed: %w", err)
+			}
+		}
+		if err != nil {
+			failed[id] = err
+			continue
+		}
+		configs[id] = config
 	}
+	return configs, failed
+}
 
-	return nil
+// apply makes the resolved configurations the loaded set
+// Unchanged configurations keep the revision rooms already point at; changed ones,
+// including those whose extended configuration changed, get a new revision.
+// Caller must hold l.mu.
+func (l *RoleConfigLoader) apply(sources, configs map[string]*RoleConfig, files map[string]string) {
+	current := l.configs
+	l.configs = make(map[string]*RoleConfig, len(configs))
+	l.files = make(map[string]string, len(files))
+	l.sources = sources
+	for id, config := range configs {
+		if previous, ok := current[id]; ok && sameContent(previous, config) && config.Revision <= previous.Revision {
+			config = previous
+		} else {
+			config.Revision = l.nextRevision(id, config.Revision)
+		}
+		l.store(config, files[id])
+	}
+}
+
+// sortedIDs returns the keys of a map keyed by configuration ID, sorted
+func sortedIDs[V any](m map[string]V) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Watch reloads the configurations whenever files in the directory change, until ctx is done
+// The directory is polled every interval; onReload receives the result of each reload.
+// Polling also catches Kubernetes ConfigMap updates, which swap a symlink rather than
+// writing the files.
+func (l *RoleConfigLoader) Watch(ctx context.Context, interval time.Duration, onReload func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := l.fingerprint()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := l.fingerprint()
+			if current == last {
+				continue
+			}
+			last = current
+			onReload(l.LoadAll())
+		}
+	}
 }
 
-// loadFile loads a single configuration file
+// fingerprint summarizes the names, sizes and modification times of the configuration files
+func (l *RoleConfigLoader) fingerprint() string {
+	files, _ := configFiles(l.configsDir)
+	var sb strings.Builder
+	for _, file := range files {
+		info, err := os.Stat(file) // Follows symlinks to the current ConfigMap data
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
+
+// nextRevision numbers a new version of a configuration after every earlier revision,
+// including those of a deleted configuration with the same ID. A higher revision
+// recorded in the file itself is kept.
+// Caller must hold l.mu.
+func (l *RoleConfigLoader) nextRevision(id string, fileRevision int) int {
+	next := max(fileRevision, 1)
+	for revision := range l.revisions[id] {
+		if revision >= next {
+			next = revision + 1
+		}
+	}
+	return next
+}
+
+// sameContent reports whether two configurations differ only in their revision
+func sameContent(a, b *RoleConfig) bool {
+	aCopy, bCopy := *a, *b
+	aCopy.Revision, bCopy.Revision = 0, 0
+	aData, aErr := json.Marshal(&aCopy)
+	bData, bErr := json.Marshal(&bCopy)
+	return aErr == nil && bErr == nil && bytes.Equal(aData, bData)
+}
+
+// loadFile reads a single JSON, YAML or TOML configuration file as written
+// It is validated once its extends are resolved.
 func (l *RoleConfigLoader) loadFile(path string) (*RoleConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
+	return decodeRoleConfig(path, data)
+}
+
+// loadError describes why a configuration failed to load
+// Validation errors are listed one per line with the file, line and field they refer to;
+// a field inherited through extends is reported where it is written.
+func loadError(sources map[string]*RoleConfig, files map[string]string, id string, err error) error {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return fmt.Errorf("failed to load %s: %w", files[id], err)
+	}
 
-	var config RoleConfig
-	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("invalid JSON: %w", err)
+	errs := []error{fmt.Errorf("failed to load %s: validation failed", files[id])}
+	lines := make(map[string]fieldLines)
+	for _, fieldErr := range validationErr.Fields {
+		file, path := fieldSource(sources, files, id, fieldErr)
+		if lines[file] == nil {
+			lines[file] = readFieldLines(file)
+		}
+		if line := lines[file](path); line > 0 {
+			errs = append(errs, fmt.Errorf("%s:%d: %s: %w", file, line, path, fieldErr))
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %s: %w", file, path, fieldErr))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	// Validate
-	if err := validateRoleConfig(&config); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+// fieldSource returns the file and path a field of a resolved configuration is written at
+// A role field is traced through extends to the configuration that defines or overrides it.
+func fieldSource(sources map[string]*RoleConfig, files map[string]string, id string, fieldErr *FieldError) (string, string) {
+	field := strings.SplitN(fieldErr.Field, ".", 2)[0]
+	for source := sources[id]; source != nil; source = sources[source.Extends] {
+		file := files[source.ID]
+		if fieldErr.RoleIndex < 0 {
+			return file, fieldErr.Field
+		}
+		if i := roleIndex(source.Roles, fieldErr.RoleID); i >= 0 {
+			return file, fmt.Sprintf("roles[%d].%s", i, fieldErr.Field)
+		}
+		if source.Extends == "" {
+			return file, fieldErr.Path()
+		}
+		for i, override := range source.Overrides {
+			var fields map[string]json.RawMessage
+			var target struct {
+				ID string `json:"id"`
+			}
+			if json.Unmarshal(override, &fields) != nil || json.Unmarshal(override, &target) != nil {
+				continue
+			}
+			if _, ok := fields[field]; ok && target.ID == fieldErr.RoleID {
+				return file, fmt.Sprintf("overrides[%d].%s", i, fieldErr.Field)
+			}
+		}
 	}
+	return files[id], fieldErr.Path()
+}
 
-	return &config, nil
+// store records a configuration as the latest revision of its ID
+// Caller must hold l.mu.
+func (l *RoleConfigLoader) store(config *RoleConfig, file string) {
+	l.configs[config.ID] = config
+	l.files[config.ID] = file
+	if l.revisions[config.ID] == nil {
+		l.revisions[config.ID] = make(map[int]*RoleConfig)
+	}
+	l.revisions[config.ID][config.Revision] = config
 }
 
 // Get returns a configuration by ID
 func (l *RoleConfigLoader) Get(id string) (*RoleConfig, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	config, ok := l.configs[id]
 	if !ok {
-		return nil, fmt.Errorf("configuration not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, id)
+	}
+	return config, nil
+}
+
+// GetRevision returns a specific revision of a configuration, even if it was since
+// updated or deleted. Revision 0 returns the latest revision.
+func (l *RoleConfigLoader) GetRevision(id string, revision int) (*RoleConfig, error) {
+	if revision == 0 {
+		return l.Get(id)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	config, ok := l.revisions[id][revision]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s revision %d", ErrConfigNotFound, id, revision)
 	}
 	return config, nil
 }
 
+// Resolve expands a configuration that is not stored, such as a room's inline role set,
+// against the loaded configurations
+func (l *RoleConfigLoader) Resolve(config *RoleConfig) (*RoleConfig, error) {
+	var base *RoleConfig
+	if config.Extends != "" {
+		var err error
+		if base, err = l.Get(config.Extends); err != nil {
+			return nil, fmt.Errorf("%w: cannot extend '%s': %v", ErrInvalidConfig, config.Extends, err)
+		}
+	}
+	resolved, err := config.expand(base)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return resolved, nil
+}
+
 // GetAll returns all loaded configurations
 func (l *RoleConfigLoader) GetAll() map[string]*RoleConfig {
-	return l.configs
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	configs := make(map[string]*RoleConfig, len(l.configs))
+	for id, config := range l.configs {
+		configs[id] = config
+	}
+	return configs
 }
 
 // GetList returns a list of configuration metadata
 func (l *RoleConfigLoader) GetList() []RoleConfigMeta {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
 	list := make([]RoleConfigMeta, 0, len(l.configs))
 	for _, config := range l.configs {
 		list = append(list, config.ToMeta())
 	}
 	return list
 }
+
+// Create validates a new configuration and writes it to the config directory
+func (l *RoleConfigLoader) Create(config *RoleConfig) error {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.configs[config.ID]; ok {
+		return fmt.Errorf("%w: %s", ErrConfigExists, config.ID)
+	}
+	return l.save(config)
+}
+
+// Update validates a replacement for an existing configuration and writes it to its file
+// Rooms created with an earlier revision keep using it.
+func (l *RoleConfigLoader) Update(id string, config *RoleConfig) error {
+	if config.ID == "" {
+		config.ID = id
+	}
+	if config.ID != id {
+		return fmt.Errorf("%w: ID '%s' does not match '%s'", ErrInvalidConfig, config.ID, id)
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.configs[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrConfigNotFound, id)
+	}
+	return l.save(config)
+}
+
+// Delete removes a configuration and its file
+// Rooms created with it keep their revision until they are closed.
+func (l *RoleConfigLoader) Delete(id string) error {
+	if id == DefaultConfigID {
+		return ErrDefaultConfig
+	}
+
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.configs[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrConfigNotFound, id)
+	}
+	for _, otherID := range sortedIDs(l.sources) {
+		if l.sources[otherID].Extends == id {
+			return fmt.Errorf("%w: '%s' extends '%s'", ErrConfigExtended, otherID, id)
+		}
+	}
+	if err := os.Remove(l.files[id]); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete configuration file: %w", err)
+	}
+
+	delete(l.configs, id)
+	delete(l.sources, id)
+	delete(l.files, id)
+	return nil
+}
+
+// save resolves and validates a configuration, writes it atomically as given and makes it
+// the latest revision. Configurations extending it are resolved again; the save is
+// refused if any of them would become invalid.
+// Caller must hold l.writeMu and l.mu.
+func (l *RoleConfigLoader) save(config *RoleConfig) error {
+	if !configIDPattern.MatchString(config.ID) {
+		return fmt.Errorf("%w: ID '%s' may only contain letters, digits, '-' and '_'", ErrInvalidConfig, config.ID)
+	}
+
+	sources := make(map[string]*RoleConfig, len(l.sources)+1)
+	for id, source := range l.sources {
+		sources[id] = source
+	}
+	sources[config.ID] = config
+
+	configs, failed := resolveSources(sources)
+	if err, ok := failed[config.ID]; ok {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	if len(failed) > 0 {
+		id := sortedIDs(failed)[0]
+		return fmt.Errorf("%w: saving '%s' would make '%s' invalid: %v", ErrInvalidConfig, config.ID, id, failed[id])
+	}
+
+	config.Revision = l.nextRevision(config.ID, 0)
+	configs[config.ID].Revision = config.Revision
+
+	files := make(map[string]string, len(l.files)+1)
+	for id, file := range l.files {
+		files[id] = file
+	}
+	if _, ok := files[config.ID]; !ok {
+		files[config.ID] = filepath.Join(l.configsDir, config.ID+".json")
+	}
+
+	// Keep the format of an existing file
+	data, err := encodeRoleConfig(files[config.ID], config)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	if err := writeFileAtomic(files[config.ID], data); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+
+	l.apply(sources, configs, files)
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory and renames it
+// over the target, so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), ".*.tmp") // Not matched by configFiles
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name()) // No-op once renamed
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(temp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
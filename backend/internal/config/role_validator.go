@@ -3,26 +3,35 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 )
 
 // validateRoleConfig performs comprehensive validation on a role configuration
 func validateRoleConfig(config *RoleConfig) error {
-	var errs []error
+	var errs []*FieldError
 
 	// Metadata validation
 	if config.ID == "" {
-		errs = append(errs, errors.New("configuration ID is required"))
+		errs = append(errs, configError("id", errors.New("configuration ID is required")))
 	}
 	if config.Name == "" {
-		errs = append(errs, errors.New("configuration name is required"))
+		errs = append(errs, configError("name", errors.New("configuration name is required")))
 	}
 	if config.Version == "" {
-		errs = append(errs, errors.New("version is required"))
+		errs = append(errs, configError("version", errors.New("version is required")))
+	}
+
+	// Bury setting validation
+	if config.Bury != "" && config.Bury != BuryNever && config.Bury != BuryOdd && config.Bury != BuryAlways {
+		errs = append(errs, configError("bury", fmt.Errorf("invalid bury setting '%s'", config.Bury)))
 	}
 
 	// Role validation
 	if len(config.Roles) == 0 {
-		errs = append(errs, errors.New("at least one role must be defined"))
+		errs = append(errs, configError("roles", errors.New("at least one role must be defined")))
 	}
 
 	seenIDs := make(map[string]bool)
@@ -34,18 +43,30 @@ func validateRoleConfig(config *RoleConfig) error {
 	for i, role := range config.Roles {
 		// Duplicate ID check
 		if seenIDs[role.ID] {
-			errs = append(errs, fmt.Errorf("duplicate role ID '%s' at index %d", role.ID, i))
+			errs = append(errs, roleError(i, role, "id", fmt.Errorf("duplicate role ID '%s' at index %d", role.ID, i)))
 		}
 		seenIDs[role.ID] = true
 
-		// Required fields
-		if role.Name == "" {
-			errs = append(errs, fmt.Errorf("role at index %d missing name", i))
+		// Required fields - a name in any locale
+		if role.NameText().Get(i18n.English) == "" {
+			errs = append(errs, roleError(i, role, "name", fmt.Errorf("role at index %d missing name", i)))
+		}
+
+		// Translations validation
+		for _, field := range []struct {
+			name string
+			text i18n.Text
+		}{{"names", role.Names}, {"descriptions", role.Descriptions}} {
+			for _, locale := range sortedLocales(field.text) {
+				if !i18n.IsSupported(locale) {
+					errs = append(errs, roleError(i, role, field.name+"."+locale, fmt.Errorf("unsupported locale '%s' in %s for role '%s' (supported: %s)", locale, field.name, role.ID, strings.Join(i18n.Supported, ", "))))
+				}
+			}
 		}
 
 		// Team validation
-		if role.Team != TeamRed && role.Team != TeamBlue && role.Team != TeamGrey {
-			errs = append(errs, fmt.Errorf("invalid team '%s' for role '%s'", role.Team, role.ID))
+		if role.Team != TeamRed && role.Team != TeamBlue && role.Team != TeamGrey && role.Team != TeamZombie {
+			errs = append(errs, roleError(i, role, "team", fmt.Errorf("invalid team '%s' for role '%s'", role.Team, role.ID)))
 		}
 
 		// Track team coverage
@@ -58,12 +79,12 @@ func validateRoleConfig(config *RoleConfig) error {
 
 		// MinPlayers validation
 		if role.MinPlayers < 6 {
-			errs = append(errs, fmt.Errorf("minPlayers must be >= 6 for role '%s'", role.ID))
+			errs = append(errs, roleError(i, role, "minPlayers", fmt.Errorf("minPlayers must be >= 6 for role '%s'", role.ID)))
 		}
 
 		// Count validation
-		if role.Count.Fixed != nil && *role.Count.Fixed < 0 {
-			errs = append(errs, fmt.Errorf("count must be >= 0 for role '%s'", role.ID))
+		for _, err := range validateRoleCount(role) {
+			errs = append(errs, roleError(i, role, "count", err))
 		}
 
 		// Type validation
@@ -75,13 +96,73 @@ func validateRoleConfig(config *RoleConfig) error {
 			RoleTypeSpecial:   true,
 		}
 		if !validTypes[role.Type] {
-			errs = append(errs, fmt.Errorf("invalid type '%s' for role '%s'", role.Type, role.ID))
+			errs = append(errs, roleError(i, role, "type", fmt.Errorf("invalid type '%s' for role '%s'", role.Type, role.ID)))
+		}
+
+		// Goal binding validation - only Grey roles have independent goals to bind
+		if role.GoalBinding != "" {
+			if role.GoalBinding != GoalBindingOutcome && role.GoalBinding != GoalBindingGoal && role.GoalBinding != GoalBindingOpposite {
+				errs = append(errs, roleError(i, role, "goalBinding", fmt.Errorf("invalid goalBinding '%s' for role '%s'", role.GoalBinding, role.ID)))
+			} else if role.Team != TeamGrey {
+				errs = append(errs, roleError(i, role, "goalBinding", fmt.Errorf("goalBinding is only supported for GREY roles, got '%s' for role '%s'", role.Team, role.ID)))
+			}
+		}
+
+		// Power validation
+		validPowers := map[PowerType]bool{
+			PowerCupid:    true,
+			PowerEris:     true,
+			PowerBoom:     true,
+			PowerAgent:    true,
+			PowerEnforcer: true,
+			PowerPsych:    true,
+		}
+		if role.Power != "" && !validPowers[role.Power] {
+			errs = append(errs, roleError(i, role, "power", fmt.Errorf("invalid power '%s' for role '%s'", role.Power, role.ID)))
+		}
+
+		// Starting condition validation
+		validConditions := map[ConditionType]bool{
+			ConditionShy:      true,
+			ConditionCoy:      true,
+			ConditionSavvy:    true,
+			ConditionParanoid: true,
+			ConditionFoolish:  true,
+			ConditionCursed:   true,
+		}
+		for _, condition := range role.Conditions {
+			if !validConditions[condition] {
+				errs = append(errs, roleError(i, role, "conditions", fmt.Errorf("invalid condition '%s' for role '%s'", condition, role.ID)))
+			}
+		}
+
+		// Card share effect validation
+		if role.OnCardShare != nil {
+			effect := role.OnCardShare
+			if len(effect.GiveConditions) == 0 && !effect.ClearConditions && !effect.SwapCards {
+				errs = append(errs, roleError(i, role, "onCardShare", fmt.Errorf("onCardShare for role '%s' must give conditions, clear conditions or swap cards", role.ID)))
+			}
+			for _, condition := range role.OnCardShare.GiveConditions {
+				if !validConditions[condition] {
+					errs = append(errs, roleError(i, role, "onCardShare.giveConditions", fmt.Errorf("invalid onCardShare condition '%s' for role '%s'", condition, role.ID)))
+				}
+			}
+		}
+
+		// Final action validation
+		if role.FinalAction != "" && role.FinalAction != FinalActionShoot && role.FinalAction != FinalActionPredict {
+			errs = append(errs, roleError(i, role, "finalAction", fmt.Errorf("invalid finalAction '%s' for role '%s'", role.FinalAction, role.ID)))
+		}
+
+		// Buried card swap validation - the swap happens at the start of one of the three rounds
+		if role.SwapBuriedRound < 0 || role.SwapBuriedRound > 3 {
+			errs = append(errs, roleError(i, role, "swapBuriedRound", fmt.Errorf("swapBuriedRound must be between 1 and 3 for role '%s'", role.ID)))
 		}
 
 		// Priority uniqueness per team (only for RED and BLUE teams)
 		if role.Team == TeamRed || role.Team == TeamBlue {
 			if teamPriorities[role.Team][role.Priority] {
-				errs = append(errs, fmt.Errorf("duplicate priority %d for %s team", role.Priority, role.Team))
+				errs = append(errs, roleError(i, role, "priority", fmt.Errorf("duplicate priority %d for %s team", role.Priority, role.Team)))
 			}
 			teamPriorities[role.Team][role.Priority] = true
 		}
@@ -89,10 +170,10 @@ func validateRoleConfig(config *RoleConfig) error {
 
 	// Team coverage check - must have both RED and BLUE leaders
 	if !hasRed {
-		errs = append(errs, errors.New("configuration must define roles for RED team"))
+		errs = append(errs, configError("roles", errors.New("configuration must define roles for RED team")))
 	}
 	if !hasBlue {
-		errs = append(errs, errors.New("configuration must define roles for BLUE team"))
+		errs = append(errs, configError("roles", errors.New("configuration must define roles for BLUE team")))
 	}
 
 	// Verify each team has a leader
@@ -107,16 +188,210 @@ func validateRoleConfig(config *RoleConfig) error {
 	}
 
 	if !hasRedLeader {
-		errs = append(errs, errors.New("configuration must define a RED team leader"))
+		errs = append(errs, configError("roles", errors.New("configuration must define a RED team leader")))
 	}
 	if !hasBlueLeader {
-		errs = append(errs, errors.New("configuration must define a BLUE team leader"))
+		errs = append(errs, configError("roles", errors.New("configuration must define a BLUE team leader")))
+	}
+
+	// Pairing validation - a role that requires another must never be dealt without it
+	for i, role := range config.Roles {
+		for _, requiredID := range role.RequiresRoles {
+			if requiredID == role.ID {
+				errs = append(errs, roleError(i, role, "requiresRoles", fmt.Errorf("role '%s' cannot require itself", role.ID)))
+				continue
+			}
+
+			required := config.FindRole(requiredID)
+			if required == nil {
+				errs = append(errs, roleError(i, role, "requiresRoles", fmt.Errorf("role '%s' requires undefined role '%s'", role.ID, requiredID)))
+				continue
+			}
+
+			if required.MinPlayers > role.MinPlayers {
+				errs = append(errs, roleError(i, role, "requiresRoles", fmt.Errorf("role '%s' requires '%s' but '%s' needs more players (%d > %d)",
+					role.ID, requiredID, requiredID, required.MinPlayers, role.MinPlayers)))
+				continue
+			}
+
+			// Check every supported player count (6-30)
+			for players := role.MinPlayers; players <= maxPlayerCount; players++ {
+				if role.Count.GetCount(players) > 0 && required.Count.GetCount(players) == 0 {
+					errs = append(errs, roleError(i, role, "requiresRoles", fmt.Errorf("role '%s' requires '%s' but '%s' has count 0 for %d players",
+						role.ID, requiredID, requiredID, players)))
+					break
+				}
+			}
+		}
+	}
+
+	// Backup validation - a backup stands in for a role of its own team
+	for i, role := range config.Roles {
+		if role.BackupFor == "" {
+			continue
+		}
+		backedUp := config.FindRole(role.BackupFor)
+		if backedUp == nil {
+			errs = append(errs, roleError(i, role, "backupFor", fmt.Errorf("role '%s' is backup for undefined role '%s'", role.ID, role.BackupFor)))
+			continue
+		}
+		if backedUp.Team != role.Team {
+			errs = append(errs, roleError(i, role, "backupFor", fmt.Errorf("role '%s' is backup for '%s' on another team", role.ID, role.BackupFor)))
+		}
+	}
+
+	// Win condition validation - room goals reference other defined roles
+	for i, role := range config.Roles {
+		if role.WinCondition != nil {
+			for _, err := range validateWinCondition(config, role) {
+				errs = append(errs, roleError(i, role, "winCondition", err))
+			}
+		}
 	}
 
 	// Combine errors
 	if len(errs) > 0 {
-		return fmt.Errorf("validation errors: %v", errs)
+		return &ValidationError{Fields: errs}
+	}
+
+	return nil
+}
+
+// ValidationError lists every problem found in a configuration
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation errors: %v", e.Fields)
+}
+
+// FieldError is a problem with one field of a configuration or of one of its roles
+type FieldError struct {
+	RoleIndex int    // Index of the role in Roles, or -1 for a configuration field
+	RoleID    string // ID of the role, if any
+	Field     string // JSON name of the field, e.g. "minPlayers" or "onCardShare.giveConditions"
+	Err       error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the field's path within the configuration, e.g. "roles[3].minPlayers"
+func (e *FieldError) Path() string {
+	if e.RoleIndex < 0 {
+		return e.Field
+	}
+	return fmt.Sprintf("roles[%d].%s", e.RoleIndex, e.Field)
+}
+
+// configError reports a problem with a configuration field
+func configError(field string, err error) *FieldError {
+	return &FieldError{RoleIndex: -1, Field: field, Err: err}
+}
+
+// roleError reports a problem with a field of the role at index i
+func roleError(i int, role RoleDefinition, field string, err error) *FieldError {
+	return &FieldError{RoleIndex: i, RoleID: role.ID, Field: field, Err: err}
+}
+
+// Validate checks a configuration that did not come from the config directory,
+// such as a room's inline role set, with the same rules as files
+func (rc *RoleConfig) Validate() error {
+	if err := validateRoleConfig(rc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+	return nil
+}
+
+// ValidateSelectedRoles checks that a host's role selection keeps paired roles together
+func (rc *RoleConfig) ValidateSelectedRoles(selectedRoles map[string]int) error {
+	var errs []error
+
+	// Iterate in a stable order so error messages are deterministic
+	roleIDs := make([]string, 0, len(selectedRoles))
+	for roleID := range selectedRoles {
+		roleIDs = append(roleIDs, roleID)
+	}
+	sort.Strings(roleIDs)
+
+	for _, roleID := range roleIDs {
+		if selectedRoles[roleID] <= 0 {
+			continue
+		}
+
+		role := rc.FindRole(roleID)
+		if role == nil {
+			errs = append(errs, fmt.Errorf("unknown role '%s'", roleID))
+			continue
+		}
+
+		for _, requiredID := range role.RequiresRoles {
+			if selectedRoles[requiredID] <= 0 {
+				errs = append(errs, fmt.Errorf("role '%s' requires '%s' to be selected", roleID, requiredID))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid role selection: %v", errs)
 	}
 
 	return nil
 }
+
+// validateWinCondition checks a Grey role's declarative win condition
+func validateWinCondition(config *RoleConfig, role RoleDefinition) []error {
+	var errs []error
+	condition := role.WinCondition
+
+	if role.Team != TeamGrey {
+		errs = append(errs, fmt.Errorf("winCondition is only supported for GREY roles, got '%s' for role '%s'", role.Team, role.ID))
+	}
+	if len(condition.SameRoomAs) == 0 && len(condition.DifferentRoomFrom) == 0 && condition.Expression == "" {
+		errs = append(errs, fmt.Errorf("winCondition for role '%s' must set sameRoomAs, differentRoomFrom or expression", role.ID))
+	}
+	if condition.Expression != "" {
+		if err := validateWinExpression(config, condition.Expression); err != nil {
+			errs = append(errs, fmt.Errorf("invalid winCondition expression for role '%s': %w", role.ID, err))
+		}
+	}
+
+	for _, roleID := range append(append([]string{}, condition.SameRoomAs...), condition.DifferentRoomFrom...) {
+		if roleID == role.ID {
+			errs = append(errs, fmt.Errorf("winCondition for role '%s' cannot reference itself", role.ID))
+		} else if config.FindRole(roleID) == nil {
+			errs = append(errs, fmt.Errorf("winCondition for role '%s' references undefined role '%s'", role.ID, roleID))
+		}
+	}
+
+	// Exclusivity compares room goals, so the rival must declare one too
+	for _, roleID := range condition.ExclusiveWith {
+		rival := config.FindRole(roleID)
+		switch {
+		case roleID == role.ID:
+			errs = append(errs, fmt.Errorf("role '%s' cannot be exclusive with itself", role.ID))
+		case rival == nil:
+			errs = append(errs, fmt.Errorf("role '%s' is exclusive with undefined role '%s'", role.ID, roleID))
+		case rival.WinCondition == nil:
+			errs = append(errs, fmt.Errorf("role '%s' is exclusive with '%s', which has no winCondition", role.ID, roleID))
+		}
+	}
+
+	return errs
+}
+
+// sortedLocales returns the locales of a text in order, so errors are reported in a stable order
+func sortedLocales(text i18n.Text) []string {
+	locales := make([]string, 0, len(text))
+	for locale := range text {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/expr"
+)
+
+// WinExpressionSymbols lists the variables and functions a winCondition expression can use
+// The outcome resolver provides a value or implementation for each of them.
+//
+// Variables:
+//   - me: the player being judged (fields: id, nickname, role, team, room, dead, leader, conditions)
+//   - winner: winning team ("RED", "BLUE" or "ZOMBIE")
+//   - presidentDead: whether the President gained the "dead" condition
+//
+// Functions taking a role ID work on the role's holder, or its backup if the card was buried.
+var WinExpressionSymbols = expr.Symbols{
+	Vars: []string{"me", "winner", "presidentDead"},
+	Funcs: map[string]int{
+		"role":             1, // role(id): player object holding the role, or nil if not in play
+		"inPlay":           1, // inPlay(id): whether someone holds the role
+		"sameRoom":         1, // sameRoom(id): whether me ends in the same room as the role
+		"cardShared":       1, // cardShared(id): whether me card shared with the role
+		"colorShared":      1, // colorShared(id): whether me color shared with the role
+		"cardShares":       0, // cardShares(): number of card shares me completed
+		"ledRoom":          1, // ledRoom(room): whether me led "RED_ROOM" or "BLUE_ROOM" at some point
+		"timesLed":         0, // timesLed(): number of times me became a leader
+		"hostageRounds":    0, // hostageRounds(): number of rounds me was sent as a hostage
+		"leftStartingRoom": 0, // leftStartingRoom(): whether me ever left the starting room
+		"countInRoom":      1, // countInRoom(room): number of players ending in the room
+	},
+}
+
+// winExpressionRoleFuncs are the functions whose argument is a role ID
+var winExpressionRoleFuncs = []string{"role", "inPlay", "sameRoom", "cardShared", "colorShared"}
+
+// winExpressionRoomFuncs are the functions whose argument is a room
+var winExpressionRoomFuncs = []string{"ledRoom", "countInRoom"}
+
+// CompileWinExpression parses and checks a winCondition expression
+func CompileWinExpression(src string) (*expr.Program, error) {
+	return expr.Compile(src, WinExpressionSymbols)
+}
+
+// validateWinExpression compiles an expression and checks the roles and rooms it names
+func validateWinExpression(config *RoleConfig, src string) error {
+	program, err := CompileWinExpression(src)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range winExpressionRoleFuncs {
+		for _, arg := range program.LiteralArgs(name) {
+			roleID, ok := arg.(string)
+			if !ok || config.FindRole(roleID) == nil {
+				errs = append(errs, fmt.Errorf("%s(%v) references undefined role", name, arg))
+			}
+		}
+	}
+	for _, name := range winExpressionRoomFuncs {
+		for _, arg := range program.LiteralArgs(name) {
+			if arg != "RED_ROOM" && arg != "BLUE_ROOM" {
+				errs = append(errs, fmt.Errorf("%s(%v) must name \"RED_ROOM\" or \"BLUE_ROOM\"", name, arg))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// UnmarshalJSON accepts either a win condition object or a bare expression string
+// Examples:
+//   - "winCondition": {"sameRoomAs": ["PRESIDENT"], "exclusiveWith": ["MISTRESS"]}
+//   - "winCondition": "sameRoom(\"PRESIDENT\") and not sameRoom(\"BOMBER\")"
+func (wc *WinCondition) UnmarshalJSON(data []byte) error {
+	var expression string
+	if err := json.Unmarshal(data, &expression); err == nil {
+		*wc = WinCondition{Expression: expression}
+		return nil
+	}
+
+	type plain WinCondition
+	return json.Unmarshal(data, (*plain)(wc))
+}
@@ -0,0 +1,234 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// eval evaluates a syntax tree node
+func eval(n node, env *Env) (Value, error) {
+	switch n := n.(type) {
+	case *literalNode:
+		return n.value, nil
+
+	case *identNode:
+		value, ok := env.Vars[n.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", n.name)
+		}
+		return normalize(value), nil
+
+	case *listNode:
+		items := make([]Value, 0, len(n.items))
+		for _, item := range n.items {
+			value, err := eval(item, env)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+
+	case *memberNode:
+		object, err := eval(n.object, env)
+		if err != nil {
+			return nil, err
+		}
+		if object == nil {
+			return nil, nil // Field of a missing object (e.g. a role not in play) is nil
+		}
+		fields, ok := object.(map[string]Value)
+		if !ok {
+			return nil, fmt.Errorf("cannot read field %q of %s at position %d", n.field, typeName(object), n.at)
+		}
+		return normalize(fields[n.field]), nil
+
+	case *callNode:
+		fn, ok := env.Funcs[n.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %q", n.name)
+		}
+		args := make([]Value, 0, len(n.args))
+		for _, arg := range n.args {
+			value, err := eval(arg, env)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, value)
+		}
+		result, err := fn(args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n.name, err)
+		}
+		return normalize(result), nil
+
+	case *unaryNode:
+		operand, err := eval(n.operand, env)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "!":
+			b, ok := operand.(bool)
+			if !ok {
+				return nil, fmt.Errorf("'not' needs true or false, got %s at position %d", typeName(operand), n.at)
+			}
+			return !b, nil
+		case "-":
+			i, ok := operand.(int64)
+			if !ok {
+				return nil, fmt.Errorf("'-' needs a number, got %s at position %d", typeName(operand), n.at)
+			}
+			return -i, nil
+		}
+
+	case *binaryNode:
+		return evalBinary(n, env)
+	}
+
+	return nil, fmt.Errorf("cannot evaluate expression at position %d", n.pos())
+}
+
+// evalBinary evaluates binary operators; 'and' and 'or' short-circuit
+func evalBinary(n *binaryNode, env *Env) (Value, error) {
+	left, err := eval(n.left, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'%s' needs true or false, got %s at position %d", n.op, typeName(left), n.at)
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := eval(n.right, env)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("'%s' needs true or false, got %s at position %d", n.op, typeName(right), n.at)
+		}
+		return r, nil
+	}
+
+	right, err := eval(n.right, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	case "in":
+		items, ok := right.([]Value)
+		if !ok {
+			return nil, fmt.Errorf("'in' needs a list on the right, got %s at position %d", typeName(right), n.at)
+		}
+		for _, item := range items {
+			if equal(left, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	l, lok := left.(int64)
+	r, rok := right.(int64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("'%s' needs numbers, got %s and %s at position %d", n.op, typeName(left), typeName(right), n.at)
+	}
+	switch n.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/", "%":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero at position %d", n.at)
+		}
+		// Division rounds down, so players/5 is the number of complete groups of five
+		quotient := l / r
+		if (l%r != 0) && ((l < 0) != (r < 0)) {
+			quotient--
+		}
+		if n.op == "/" {
+			return quotient, nil
+		}
+		return l - quotient*r, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator %q at position %d", n.op, n.at)
+}
+
+// equal compares two values; lists and objects are never equal
+func equal(a, b Value) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case bool, int64, string:
+		return a == b
+	}
+	return false
+}
+
+// normalize converts host values to expression values
+// Named string types (e.g. models.RoomColor) become plain strings so they compare equal
+// to string literals, and slices of them become lists.
+func normalize(v any) Value {
+	switch v := v.(type) {
+	case nil, bool, int64, string, []Value, map[string]Value:
+		return v
+	case int:
+		return int64(v)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Int, reflect.Int32:
+		return rv.Int()
+	case reflect.Slice:
+		items := make([]Value, rv.Len())
+		for i := range items {
+			items[i] = normalize(rv.Index(i).Interface())
+		}
+		return items
+	}
+	return v
+}
+
+// typeName describes a value's type for error messages
+func typeName(v Value) string {
+	switch v.(type) {
+	case nil:
+		return "nil"
+	case bool:
+		return "true/false"
+	case int64:
+		return "number"
+	case string:
+		return "text"
+	case []Value:
+		return "list"
+	case map[string]Value:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,163 @@
+// Package expr implements a small, sandboxed expression language for role configuration.
+//
+// Expressions read variables and call functions that the host provides; there are
+// no loops, assignments or side effects, so evaluation always terminates.
+//
+//	sameRoom("PRESIDENT") and not sameRoom("BOMBER")
+//	"SHY" in me.conditions or hostageRounds() >= 2
+//	max(1, players/5)
+//
+// Values are nil, bool, int64, string, lists ([]Value) and objects (map[string]Value).
+// Numbers are integers; division rounds down.
+package expr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Value is a runtime value: nil, bool, int64, string, []Value or map[string]Value
+type Value = any
+
+// Func is a host function callable from an expression
+type Func func(args []Value) (Value, error)
+
+// Symbols lists the names an expression may use, checked at compile time
+type Symbols struct {
+	Vars  []string       // Variable names
+	Funcs map[string]int // Function names and their argument count (-1 for any)
+}
+
+// Env provides variable values and function implementations at evaluation time
+type Env struct {
+	Vars  map[string]Value
+	Funcs map[string]Func
+}
+
+// Program is a parsed and checked expression
+type Program struct {
+	src  string
+	root node
+}
+
+// Compile parses an expression and checks that it only uses the given symbols
+func Compile(src string, symbols Symbols) (*Program, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := check(root, symbols); err != nil {
+		return nil, err
+	}
+	return &Program{src: src, root: root}, nil
+}
+
+// String returns the source of the expression
+func (p *Program) String() string {
+	return p.src
+}
+
+// Eval evaluates the expression against the environment
+func (p *Program) Eval(env *Env) (Value, error) {
+	return eval(p.root, env)
+}
+
+// EvalBool evaluates the expression and requires a boolean result
+func (p *Program) EvalBool(env *Env) (bool, error) {
+	result, err := p.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression must evaluate to true or false, got %s", typeName(result))
+	}
+	return b, nil
+}
+
+// EvalInt evaluates the expression and requires a number result
+func (p *Program) EvalInt(env *Env) (int64, error) {
+	result, err := p.Eval(env)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("expression must evaluate to a number, got %s", typeName(result))
+	}
+	return i, nil
+}
+
+// check verifies identifiers and function calls against the known symbols
+func check(n node, symbols Symbols) error {
+	vars := make(map[string]bool, len(symbols.Vars))
+	for _, name := range symbols.Vars {
+		vars[name] = true
+	}
+
+	var errs []error
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *identNode:
+			if !vars[n.name] {
+				errs = append(errs, fmt.Errorf("unknown variable %q at position %d", n.name, n.at))
+			}
+		case *callNode:
+			arity, ok := symbols.Funcs[n.name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("unknown function %q at position %d", n.name, n.at))
+			} else if arity >= 0 && len(n.args) != arity {
+				errs = append(errs, fmt.Errorf("function %q takes %d argument(s), got %d at position %d", n.name, arity, len(n.args), n.at))
+			}
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case *listNode:
+			for _, item := range n.items {
+				walk(item)
+			}
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		case *memberNode:
+			walk(n.object)
+		}
+	}
+	walk(n)
+
+	return errors.Join(errs...)
+}
+
+// LiteralArgs returns the literal arguments passed to every call of the named function
+// Hosts use it to validate references such as role IDs when an expression is loaded.
+func (p *Program) LiteralArgs(funcName string) []Value {
+	var args []Value
+	var walk func(n node)
+	walk = func(n node) {
+		switch n := n.(type) {
+		case *callNode:
+			for _, arg := range n.args {
+				if literal, ok := arg.(*literalNode); ok && n.name == funcName {
+					args = append(args, literal.value)
+				}
+				walk(arg)
+			}
+		case *listNode:
+			for _, item := range n.items {
+				walk(item)
+			}
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		case *memberNode:
+			walk(n.object)
+		}
+	}
+	walk(p.root)
+	return args
+}
@@ -0,0 +1,197 @@
+package expr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testSymbols = Symbols{
+	Vars:  []string{"me", "count"},
+	Funcs: map[string]int{"sameRoom": 1, "fail": 0, "any": -1},
+}
+
+func testEnv() *Env {
+	return &Env{
+		Vars: map[string]Value{
+			"me": map[string]Value{
+				"room":       "BLUE_ROOM",
+				"conditions": []string{"SHY"},
+				"leader":     nil,
+			},
+			"count": 2,
+		},
+		Funcs: map[string]Func{
+			"sameRoom": func(args []Value) (Value, error) {
+				return args[0] == "PRESIDENT", nil
+			},
+			"fail": func([]Value) (Value, error) {
+				return nil, errors.New("boom")
+			},
+			"any": func(args []Value) (Value, error) {
+				return len(args), nil
+			},
+		},
+	}
+}
+
+func TestEvalBool(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{`true`, true},
+		{`not false`, true},
+		{`sameRoom("PRESIDENT") and not sameRoom('BOMBER')`, true},
+		{`sameRoom("BOMBER") || sameRoom("PRESIDENT")`, true},
+		{`me.room == "BLUE_ROOM"`, true},
+		{`me.room != "BLUE_ROOM"`, false},
+		{`"SHY" in me.conditions`, true},
+		{`"DEAD" in me.conditions`, false},
+		{`me.room in ["RED_ROOM", "BLUE_ROOM"]`, true},
+		{`me.leader == nil`, true},
+		{`me.missing.field == nil`, true},
+		{`count >= 2 and count < 3`, true},
+		{`count + 1 == 3`, true},
+		{`count - 3 == -1`, true},
+		{`any(1, 2, 3) == 3`, true},
+		{`any() == 0`, true},
+		{`true or false and false`, true},
+		{`(true or false) and false`, false},
+		{`false and fail()`, false}, // short-circuit skips the failing call
+		{`true or fail()`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			program, err := Compile(tt.src, testSymbols)
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+			got, err := program.EvalBool(testEnv())
+			if err != nil {
+				t.Fatalf("EvalBool failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{``, "unexpected end"},
+		{`sameRoom("PRESIDENT"`, `expected ")"`},
+		{`"unterminated`, "unterminated string"},
+		{`me.`, "expected field name"},
+		{`true true`, "unexpected"},
+		{`me # 1`, "unexpected character"},
+		{`unknown`, `unknown variable "unknown"`},
+		{`nope()`, `unknown function "nope"`},
+		{`sameRoom()`, "takes 1 argument(s), got 0"},
+		{`fail(1)`, "takes 0 argument(s), got 1"},
+		{strings.Repeat("(", MaxDepth+1) + "true" + strings.Repeat(")", MaxDepth+1), "nested deeper"},
+		{strings.Repeat("not ", MaxDepth+1) + "true", "nested deeper"},
+		{strings.Repeat("a", MaxSourceLength+1), "longer than"},
+	}
+
+	for _, tt := range tests {
+		name := tt.src
+		if len(name) > 40 {
+			name = name[:40]
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := Compile(tt.src, testSymbols)
+			if err == nil {
+				t.Fatalf("Expected error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEvalBool_Errors(t *testing.T) {
+	tests := []struct {
+		src     string
+		wantErr string
+	}{
+		{`count`, "must evaluate to true or false"},
+		{`count and true`, "needs true or false"},
+		{`not me.room`, "needs true or false"},
+		{`me.room > 1`, "needs numbers"},
+		{`"SHY" in me.room`, "needs a list"},
+		{`count.field == 1`, "cannot read field"},
+		{`fail()`, "fail: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			program, err := Compile(tt.src, testSymbols)
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+			_, err = program.EvalBool(testEnv())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEvalInt(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    int64
+		wantErr string
+	}{
+		{src: `count * 3 + 1`, want: 7},
+		{src: `(count + 1) * 3`, want: 9},
+		{src: `11 / count`, want: 5},
+		{src: `-7 / count`, want: -4}, // rounds down, not toward zero
+		{src: `-7 % count`, want: 1},
+		{src: `11 % 4`, want: 3},
+		{src: `count / 0`, wantErr: "division by zero"},
+		{src: `count > 1`, wantErr: "must evaluate to a number"},
+		{src: `me.room * 2`, wantErr: "needs numbers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			program, err := Compile(tt.src, testSymbols)
+			if err != nil {
+				t.Fatalf("Compile failed: %v", err)
+			}
+			got, err := program.EvalInt(testEnv())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvalInt failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProgram_LiteralArgs(t *testing.T) {
+	program, err := Compile(`sameRoom("PRESIDENT") or (any(sameRoom("BOMBER")) == 1 and sameRoom(me.room))`, testSymbols)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	got := program.LiteralArgs("sameRoom")
+	if len(got) != 2 || got[0] != "PRESIDENT" || got[1] != "BOMBER" {
+		t.Errorf("Expected [PRESIDENT BOMBER], got %v", got)
+	}
+}
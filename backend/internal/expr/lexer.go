@@ -0,0 +1,132 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies a lexical token
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenDot
+)
+
+// token is a single lexical token with its position in the source
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keywordOperators are word forms of the logical and membership operators
+var keywordOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+	"in":  "in",
+}
+
+// twoCharOperators are checked before single-character operators
+var twoCharOperators = []string{"&&", "||", "==", "!=", "<=", ">="}
+
+// lex splits the source into tokens
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			word := string(runes[start:i])
+			if op, ok := keywordOperators[word]; ok {
+				tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+			} else {
+				tokens = append(tokens, token{kind: tokenIdent, text: word, pos: start})
+			}
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var sb strings.Builder
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: sb.String(), pos: start})
+
+		default:
+			start := i
+			if i+1 < len(runes) {
+				pair := string(runes[i : i+2])
+				matched := false
+				for _, op := range twoCharOperators {
+					if pair == op {
+						tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start})
+						i += 2
+						matched = true
+						break
+					}
+				}
+				if matched {
+					continue
+				}
+			}
+
+			kind := tokenOperator
+			switch r {
+			case '(':
+				kind = tokenLParen
+			case ')':
+				kind = tokenRParen
+			case '[':
+				kind = tokenLBracket
+			case ']':
+				kind = tokenRBracket
+			case ',':
+				kind = tokenComma
+			case '.':
+				kind = tokenDot
+			case '!', '<', '>', '+', '-', '*', '/', '%':
+			default:
+				return nil, fmt.Errorf("unexpected character %q at position %d", r, start)
+			}
+			tokens = append(tokens, token{kind: kind, text: string(r), pos: start})
+			i++
+		}
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
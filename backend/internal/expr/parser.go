@@ -0,0 +1,267 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Limits that keep expressions from role files cheap to check and evaluate
+const (
+	MaxSourceLength = 2000 // Characters in a single expression
+	MaxDepth        = 32   // Nesting depth of the syntax tree
+)
+
+// node is an expression syntax tree node
+type node interface {
+	pos() int
+}
+
+type (
+	literalNode struct {
+		at    int
+		value Value
+	}
+	identNode struct {
+		at   int
+		name string
+	}
+	listNode struct {
+		at    int
+		items []node
+	}
+	unaryNode struct {
+		at      int
+		op      string
+		operand node
+	}
+	binaryNode struct {
+		at          int
+		op          string
+		left, right node
+	}
+	memberNode struct {
+		at     int
+		object node
+		field  string
+	}
+	callNode struct {
+		at   int
+		name string
+		args []node
+	}
+)
+
+func (n *literalNode) pos() int { return n.at }
+func (n *identNode) pos() int   { return n.at }
+func (n *listNode) pos() int    { return n.at }
+func (n *unaryNode) pos() int   { return n.at }
+func (n *binaryNode) pos() int  { return n.at }
+func (n *memberNode) pos() int  { return n.at }
+func (n *callNode) pos() int    { return n.at }
+
+// binaryPrecedence orders binary operators; higher binds tighter
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3, "<": 3, "<=": 3, ">": 3, ">=": 3, "in": 3,
+	"+": 4, "-": 4,
+	"*": 5, "/": 5, "%": 5,
+}
+
+// parser is a precedence-climbing parser over a token list
+type parser struct {
+	tokens []token
+	next   int
+}
+
+// parse builds the syntax tree for an expression
+func parse(src string) (node, error) {
+	if len(src) > MaxSourceLength {
+		return nil, fmt.Errorf("expression is longer than %d characters", MaxSourceLength)
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr(0, 0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+	return root, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	tok := p.advance()
+	if tok.kind != kind {
+		if tok.kind == tokenEOF {
+			return fmt.Errorf("expected %q at end of expression", text)
+		}
+		return fmt.Errorf("expected %q at position %d, got %q", text, tok.pos, tok.text)
+	}
+	return nil
+}
+
+// parseExpr parses binary operators whose precedence is above minPrecedence
+func (p *parser) parseExpr(minPrecedence, depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("expression is nested deeper than %d levels", MaxDepth)
+	}
+
+	left, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		precedence, ok := binaryPrecedence[tok.text]
+		if tok.kind != tokenOperator || !ok || precedence <= minPrecedence {
+			return left, nil
+		}
+		p.advance()
+
+		right, err := p.parseExpr(precedence, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{at: tok.pos, op: tok.text, left: left, right: right}
+	}
+}
+
+// parseUnary parses prefix operators (!, not, -)
+func (p *parser) parseUnary(depth int) (node, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("expression is nested deeper than %d levels", MaxDepth)
+	}
+
+	tok := p.peek()
+	if tok.kind == tokenOperator && (tok.text == "!" || tok.text == "-") {
+		p.advance()
+		operand, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{at: tok.pos, op: tok.text, operand: operand}, nil
+	}
+
+	return p.parsePostfix(depth)
+}
+
+// parsePostfix parses a primary expression followed by field accesses
+func (p *parser) parsePostfix(depth int) (node, error) {
+	result, err := p.parsePrimary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenDot {
+		dot := p.advance()
+		field := p.advance()
+		if field.kind != tokenIdent {
+			return nil, fmt.Errorf("expected field name after '.' at position %d", dot.pos)
+		}
+		result = &memberNode{at: dot.pos, object: result, field: field.text}
+	}
+	return result, nil
+}
+
+// parsePrimary parses literals, identifiers, calls, lists and parenthesized expressions
+func (p *parser) parsePrimary(depth int) (node, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenNumber:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", tok.text, tok.pos)
+		}
+		return &literalNode{at: tok.pos, value: n}, nil
+
+	case tokenString:
+		return &literalNode{at: tok.pos, value: tok.text}, nil
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{at: tok.pos, value: true}, nil
+		case "false":
+			return &literalNode{at: tok.pos, value: false}, nil
+		case "nil", "null":
+			return &literalNode{at: tok.pos, value: nil}, nil
+		}
+		if p.peek().kind == tokenLParen {
+			p.advance()
+			args, err := p.parseList(tokenRParen, ")", depth)
+			if err != nil {
+				return nil, err
+			}
+			return &callNode{at: tok.pos, name: tok.text, args: args}, nil
+		}
+		return &identNode{at: tok.pos, name: tok.text}, nil
+
+	case tokenLBracket:
+		items, err := p.parseList(tokenRBracket, "]", depth)
+		if err != nil {
+			return nil, err
+		}
+		return &listNode{at: tok.pos, items: items}, nil
+
+	case tokenLParen:
+		inner, err := p.parseExpr(0, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(tokenRParen, ")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+}
+
+// parseList parses comma-separated expressions up to the closing token
+func (p *parser) parseList(closing tokenKind, closingText string, depth int) ([]node, error) {
+	var items []node
+	if p.peek().kind == closing {
+		p.advance()
+		return items, nil
+	}
+
+	for {
+		item, err := p.parseExpr(0, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if p.peek().kind == tokenComma {
+			p.advance()
+			continue
+		}
+		if err := p.expect(closing, closingText); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+}
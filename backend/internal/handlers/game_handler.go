@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,6 +21,11 @@ func NewGameHandler(gameService *services.GameService) *GameHandler {
 	}
 }
 
+// StartGameRequest is the optional body of a start game request
+type StartGameRequest struct {
+	AutoFix bool `json:"autoFix"`
+}
+
 // T073: Create POST /api/v1/rooms/{roomCode}/game/start handler
 func (h *GameHandler) StartGame(c *gin.Context) {
 	roomCode := c.Param("roomCode")
@@ -33,9 +39,35 @@ func (h *GameHandler) StartGame(c *gin.Context) {
 		return
 	}
 
+	// Optional body: {"autoFix": true} adjusts a role selection that cannot be dealt
+	var req StartGameRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    "INVALID_REQUEST",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
 	// Start the game
-	_, err := h.gameService.StartGame(roomCode)
+	_, err := h.gameService.StartGameWithOptions(roomCode, services.StartGameOptions{AutoFix: req.AutoFix})
 	if err != nil {
+		// Roles that cannot be dealt: report every problem and what auto-fix would change
+		var assignErr *services.RoleAssignmentError
+		if errors.As(err, &assignErr) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"code":    assignErr.Code,
+				"message": "Roles cannot be dealt with the current setup",
+				"details": gin.H{
+					"errors": assignErr.Errors,
+					"fix":    assignErr.Fix,
+				},
+			})
+			return
+		}
+
 		// Handle specific error types
 		if err.Error() == "room not found" || err == models.ErrRoomNotFound {
 			c.JSON(http.StatusNotFound, gin.H{
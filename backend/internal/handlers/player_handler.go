@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/services"
 )
@@ -26,10 +27,13 @@ type UpdateNicknameRequest struct {
 }
 
 // T040: Create POST /api/v1/rooms/{roomCode}/players handler
+// The player's language is the "locale" query parameter if supported, otherwise the
+// browser's Accept-Language.
 func (h *PlayerHandler) JoinRoom(c *gin.Context) {
 	roomCode := c.Param("roomCode")
+	locale := i18n.Negotiate(c.Query("locale"), c.GetHeader("Accept-Language"))
 
-	player, err := h.playerService.JoinRoom(roomCode)
+	player, err := h.playerService.JoinRoomWithLocale(roomCode, locale)
 	if err != nil {
 		switch err {
 		case models.ErrRoomNotFound:
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalee/two-rooms-and-a-boom/internal/services"
+)
+
+// PowerHandler handles role power HTTP requests
+type PowerHandler struct {
+	powerService *services.PowerService
+}
+
+// NewPowerHandler creates a new PowerHandler instance
+func NewPowerHandler(powerService *services.PowerService) *PowerHandler {
+	return &PowerHandler{
+		powerService: powerService,
+	}
+}
+
+// UsePowerRequest represents a power activation request
+type UsePowerRequest struct {
+	TargetIDs []string `json:"targetIds" binding:"required"`
+}
+
+// UsePower activates the player's role power
+// POST /api/v1/rooms/:roomCode/powers/use
+func (h *PowerHandler) UsePower(c *gin.Context) {
+	roomCode := c.Param("roomCode")
+	playerID := c.GetHeader("X-Player-ID")
+
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "player ID required"})
+		return
+	}
+
+	var req UsePowerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.powerService.UsePower(roomCode, playerID, req.TargetIDs); err != nil {
+		log.Printf("[ERROR] Failed to use power: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "power used"})
+}
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/services"
 )
 
 // RoleConfigHandler handles role configuration-related HTTP requests
@@ -44,3 +47,128 @@ func (h *RoleConfigHandler) GetRoleConfig(c *gin.Context) {
 
 	c.JSON(http.StatusOK, config)
 }
+
+// PreviewRoleConfigRequest represents the request body for previewing a role distribution
+type PreviewRoleConfigRequest struct {
+	PlayerCount   int            `json:"playerCount" binding:"required,min=1,max=30"`
+	SelectedRoles map[string]int `json:"selectedRoles"` // Optional, selected role IDs and their counts
+	Revision      int            `json:"revision"`      // Optional, defaults to the latest revision
+}
+
+// PreviewRoleConfig handles POST /api/v1/role-configs/:id/preview
+// Returns the cards each team would be dealt, plus anything that would stop the game from starting
+func (h *RoleConfigHandler) PreviewRoleConfig(c *gin.Context) {
+	var req PreviewRoleConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	roleConfig, err := h.roleLoader.GetRevision(c.Param("id"), req.Revision)
+	if err != nil {
+		respondRoleConfigError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, services.PlanRoleDistribution(roleConfig, req.PlayerCount, req.SelectedRoles))
+}
+
+// CreateRoleConfig handles POST /api/v1/role-configs
+// Validates a new role configuration and writes it to the config directory
+func (h *RoleConfigHandler) CreateRoleConfig(c *gin.Context) {
+	var roleConfig config.RoleConfig
+	if err := c.ShouldBindJSON(&roleConfig); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_REQUEST",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.roleLoader.Create(&roleConfig); err != nil {
+		respondRoleConfigError(c, err)
+		return
+	}
+
+	log.Printf("[INFO] Role configuration created: id=%s revision=%d", roleConfig.ID, roleConfig.Revision)
+	h.respondResolved(c, http.StatusCreated, roleConfig.ID)
+}
+
+// UpdateRoleConfig handles PUT /api/v1/role-configs/:id
+// Replaces a role configuration; rooms created earlier keep the revision they started with
+func (h *RoleConfigHandler) UpdateRoleConfig(c *gin.Context) {
+	var roleConfig config.RoleConfig
+	if err := c.ShouldBindJSON(&roleConfig); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_REQUEST",
+			"message": "Invalid request body",
+		})
+		return
+	}
+
+	if err := h.roleLoader.Update(c.Param("id"), &roleConfig); err != nil {
+		respondRoleConfigError(c, err)
+		return
+	}
+
+	log.Printf("[INFO] Role configuration updated: id=%s revision=%d", roleConfig.ID, roleConfig.Revision)
+	h.respondResolved(c, http.StatusOK, roleConfig.ID)
+}
+
+// DeleteRoleConfig handles DELETE /api/v1/role-configs/:id
+// Removes a role configuration and its file
+func (h *RoleConfigHandler) DeleteRoleConfig(c *gin.Context) {
+	configID := c.Param("id")
+	if err := h.roleLoader.Delete(configID); err != nil {
+		respondRoleConfigError(c, err)
+		return
+	}
+
+	log.Printf("[INFO] Role configuration deleted: id=%s", configID)
+	c.Status(http.StatusNoContent)
+}
+
+// respondResolved responds with a saved configuration as clients see it, with extends resolved
+func (h *RoleConfigHandler) respondResolved(c *gin.Context, status int, configID string) {
+	roleConfig, err := h.roleLoader.Get(configID)
+	if err != nil {
+		respondRoleConfigError(c, err)
+		return
+	}
+	c.JSON(status, roleConfig)
+}
+
+// respondRoleConfigError maps role configuration loader errors to HTTP responses
+func respondRoleConfigError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, config.ErrConfigNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    "ROLE_CONFIG_NOT_FOUND",
+			"message": "Role configuration not found",
+		})
+	case errors.Is(err, config.ErrConfigExists):
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    "ROLE_CONFIG_EXISTS",
+			"message": "Role configuration already exists",
+		})
+	case errors.Is(err, config.ErrDefaultConfig), errors.Is(err, config.ErrConfigExtended):
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    "ROLE_CONFIG_PROTECTED",
+			"message": err.Error(),
+		})
+	case errors.Is(err, config.ErrInvalidConfig):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ROLE_CONFIG",
+			"message": err.Error(),
+		})
+	default:
+		log.Printf("[ERROR] Failed to save role configuration: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    "SAVE_ROLE_CONFIG_FAILED",
+			"message": "Failed to save role configuration",
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/services"
 )
 
@@ -30,6 +32,8 @@ type CreateRoomRequest struct {
 	IsPublic      *bool          `json:"isPublic"`      // Optional, defaults to true if not provided
 	RoleConfigID  string         `json:"roleConfigId"`  // Optional, defaults to "standard" if not provided
 	SelectedRoles map[string]int `json:"selectedRoles"` // Optional, selected role IDs and their counts
+	// Optional inline role set for this room only; replaces RoleConfigID when provided
+	CustomRoleConfig *config.RoleConfig `json:"customRoleConfig"`
 }
 
 // T038: Create POST /api/v1/rooms handler
@@ -49,6 +53,17 @@ func (h *RoomHandler) CreateRoom(c *gin.Context) {
 		isPublic = *req.IsPublic
 	}
 
+	// An inline role set is validated by the room service with the configuration file rules
+	if req.CustomRoleConfig != nil {
+		room, err := h.roomService.CreateCustomRoom(req.MaxPlayers, isPublic, req.CustomRoleConfig, req.SelectedRoles)
+		if err != nil {
+			respondRoleSetError(c, err, "CREATE_ROOM_FAILED")
+			return
+		}
+		c.JSON(http.StatusCreated, room)
+		return
+	}
+
 	// Default to "standard" if not specified
 	roleConfigID := req.RoleConfigID
 	if roleConfigID == "" {
@@ -57,13 +72,23 @@ func (h *RoomHandler) CreateRoom(c *gin.Context) {
 
 	// Validate role config exists
 	if h.roleLoader != nil {
-		if _, err := h.roleLoader.Get(roleConfigID); err != nil {
+		roleConfig, err := h.roleLoader.Get(roleConfigID)
+		if err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{
 				"code":    "INVALID_ROLE_CONFIG",
 				"message": fmt.Sprintf("Role configuration '%s' not found", roleConfigID),
 			})
 			return
 		}
+
+		// Validate paired roles are selected together
+		if err := roleConfig.ValidateSelectedRoles(req.SelectedRoles); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    "INVALID_ROLE_SELECTION",
+				"message": err.Error(),
+			})
+			return
+		}
 	}
 
 	room, err := h.roomService.CreateRoom(req.MaxPlayers, isPublic, roleConfigID, req.SelectedRoles)
@@ -214,6 +239,95 @@ func (h *RoomHandler) UpdateRoomVisibility(c *gin.Context) {
 	})
 }
 
+// UpdateRoleSetRequest represents the request body for changing a room's roles in the lobby
+type UpdateRoleSetRequest struct {
+	RoleConfigID     string             `json:"roleConfigId"`     // Server-wide configuration, defaults to "standard"
+	SelectedRoles    map[string]int     `json:"selectedRoles"`    // Optional, selected role IDs and their counts
+	CustomRoleConfig *config.RoleConfig `json:"customRoleConfig"` // Optional inline role set; replaces RoleConfigID
+}
+
+// UpdateRoleSet handles PUT /api/v1/rooms/:roomCode/role-config
+// The room owner can switch configurations or supply an inline role set before the game starts
+func (h *RoomHandler) UpdateRoleSet(c *gin.Context) {
+	roomCode := c.Param("roomCode")
+
+	// Validate room code format
+	if len(roomCode) != 6 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ROOM_CODE",
+			"message": "Room code must be 6 characters",
+		})
+		return
+	}
+
+	var req UpdateRoleSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_REQUEST",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	playerID := c.Query("playerId")
+	if playerID == "" {
+		playerID = c.GetHeader("X-Player-ID")
+	}
+
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    "UNAUTHORIZED",
+			"message": "Player ID required",
+		})
+		return
+	}
+
+	room, err := h.roomService.UpdateRoleSet(roomCode, playerID, req.RoleConfigID, req.CustomRoleConfig, req.SelectedRoles)
+	if err != nil {
+		respondRoleSetError(c, err, "UPDATE_ROLE_SET_FAILED")
+		return
+	}
+
+	c.JSON(http.StatusOK, room)
+}
+
+// respondRoleSetError maps errors from choosing a room's roles to HTTP responses
+// Unrecognized errors are reported with fallbackCode.
+func respondRoleSetError(c *gin.Context, err error, fallbackCode string) {
+	switch {
+	case errors.Is(err, models.ErrRoomNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    "ROOM_NOT_FOUND",
+			"message": "Room not found",
+		})
+	case errors.Is(err, models.ErrNotRoomOwner):
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    "FORBIDDEN",
+			"message": "Only the room owner can change the role set",
+		})
+	case errors.Is(err, models.ErrGameAlreadyStarted):
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    "GAME_ALREADY_STARTED",
+			"message": "Roles can only be changed in the lobby",
+		})
+	case errors.Is(err, config.ErrConfigNotFound), errors.Is(err, config.ErrInvalidConfig):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ROLE_CONFIG",
+			"message": err.Error(),
+		})
+	case errors.Is(err, models.ErrInvalidRoleSelection):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "INVALID_ROLE_SELECTION",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    fallbackCode,
+			"message": err.Error(),
+		})
+	}
+}
+
 // parseIntParam parses an integer query parameter
 func parseIntParam(value string, paramName string) (int, error) {
 	result, err := strconv.Atoi(value)
@@ -330,6 +330,43 @@ func (h *RoundHandler) LeaderReady(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "leader marked as ready"})
 }
 
+// SubmitFinalActionRequest represents an end-of-game choice
+// Sniper sends targetId; Gambler sends prediction (RED, BLUE or NONE)
+type SubmitFinalActionRequest struct {
+	TargetID   string `json:"targetId"`
+	Prediction string `json:"prediction"`
+}
+
+// SubmitFinalAction records a choice during the final actions phase
+// POST /api/v1/rooms/:roomCode/final-actions
+func (h *RoundHandler) SubmitFinalAction(c *gin.Context) {
+	roomCode := c.Param("roomCode")
+	playerID := c.GetHeader("X-Player-ID")
+
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "player ID required"})
+		return
+	}
+
+	var req SubmitFinalActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		return
+	}
+
+	action, err := h.roundManager.SubmitFinalAction(roomCode, playerID, req.TargetID, models.TeamColor(req.Prediction))
+	if err != nil {
+		log.Printf("[ERROR] Failed to submit final action: %v", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"actionType": action.Type,
+		"message":    "final action recorded",
+	})
+}
+
 // RegisterRoutes registers round-related routes
 func (h *RoundHandler) RegisterRoutes(router *gin.Engine) {
 	api := router.Group("/api/v1")
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/services"
+)
+
+// ShareHandler handles card/color share HTTP requests
+type ShareHandler struct {
+	shareService *services.ShareService
+}
+
+// NewShareHandler creates a new ShareHandler instance
+func NewShareHandler(shareService *services.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// RequestShareRequest represents a share offer
+type RequestShareRequest struct {
+	TargetID  string `json:"targetId" binding:"required"`
+	ShareType string `json:"shareType" binding:"required"`
+}
+
+// RequestShare offers a card or color share to another player
+// POST /api/v1/rooms/:roomCode/shares
+func (h *ShareHandler) RequestShare(c *gin.Context) {
+	roomCode := c.Param("roomCode")
+	playerID := c.GetHeader("X-Player-ID")
+
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "player ID required"})
+		return
+	}
+
+	var req RequestShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		return
+	}
+
+	share, err := h.shareService.RequestShare(roomCode, playerID, req.TargetID, models.ShareType(req.ShareType))
+	if err != nil {
+		log.Printf("[ERROR] Failed to request share: %v", err)
+		c.JSON(http.StatusBadRequest, shareErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shareId":   share.ID,
+		"shareType": share.Type,
+		"status":    share.Status,
+	})
+}
+
+// RespondShareRequest represents a target's answer to a share offer
+type RespondShareRequest struct {
+	Accept *bool `json:"accept" binding:"required"`
+}
+
+// RespondToShare accepts or declines a pending share
+// POST /api/v1/rooms/:roomCode/shares/:shareId/respond
+func (h *ShareHandler) RespondToShare(c *gin.Context) {
+	roomCode := c.Param("roomCode")
+	shareID := c.Param("shareId")
+	playerID := c.GetHeader("X-Player-ID")
+
+	if playerID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "player ID required"})
+		return
+	}
+
+	var req RespondShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		return
+	}
+
+	share, err := h.shareService.RespondToShare(roomCode, shareID, playerID, *req.Accept)
+	if err != nil {
+		log.Printf("[ERROR] Failed to respond to share: %v", err)
+		c.JSON(http.StatusBadRequest, shareErrorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shareId": share.ID,
+		"status":  share.Status,
+	})
+}
+
+// shareErrorResponse builds the error body, adding the rejection code when a condition blocked the share
+func shareErrorResponse(err error) gin.H {
+	var shareErr *models.ShareError
+	if errors.As(err, &shareErr) {
+		return gin.H{
+			"error":    err.Error(),
+			"code":     shareErr.Code,
+			"playerId": shareErr.PlayerID,
+		}
+	}
+	return gin.H{"error": err.Error()}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/services"
 	ws "github.com/kalee/two-rooms-and-a-boom/internal/websocket"
 )
@@ -45,7 +46,7 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 	playerID := c.Query("playerId")
 
 	// Verify room exists
-	_, err := h.roomService.GetRoom(roomCode)
+	room, err := h.roomService.GetRoom(roomCode)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"code":    "ROOM_NOT_FOUND",
@@ -72,6 +73,18 @@ func (h *WebSocketHandler) HandleWebSocket(c *gin.Context) {
 		log.Printf("[INFO] WebSocket client registered with playerID: %s", playerID)
 	}
 
+	// Messages are sent in the locale the player joined with, or else negotiated here
+	locale := ""
+	for _, player := range room.Players {
+		if player.ID == playerID {
+			locale = player.Locale
+		}
+	}
+	if locale == "" {
+		locale = i18n.Negotiate(c.Query("locale"), c.GetHeader("Accept-Language"))
+	}
+	client.SetLocale(locale)
+
 	// Register client with hub
 	h.registerClient(client)
 
@@ -0,0 +1,349 @@
+package i18n
+
+// catalog holds the messages the server sends to players, by message ID
+// Formats use fmt verbs; every message is written in every Supported locale.
+var catalog = map[string]Text{
+	// Shared pieces
+	"list": {
+		Korean:   "%s",
+		English:  "%s",
+		Japanese: "%s",
+	},
+	"list.separator": {
+		Korean:   ", ",
+		English:  ", ",
+		Japanese: "、",
+	},
+	"team.RED": {
+		Korean:   "레드",
+		English:  "Red",
+		Japanese: "レッド",
+	},
+	"team.BLUE": {
+		Korean:   "블루",
+		English:  "Blue",
+		Japanese: "ブルー",
+	},
+	"team.GREY": {
+		Korean:   "그레이",
+		English:  "Grey",
+		Japanese: "グレー",
+	},
+	"team.ZOMBIE": {
+		Korean:   "좀비",
+		English:  "Zombie",
+		Japanese: "ゾンビ",
+	},
+	"system.name": {
+		Korean:   "시스템",
+		English:  "System",
+		Japanese: "システム",
+	},
+
+	// Rooms and players
+	"player.default_nickname": {
+		Korean:   "플레이어%d",
+		English:  "Player%d",
+		Japanese: "プレイヤー%d",
+	},
+	"room.closed.owner_left": {
+		Korean:   "방장이 나갔습니다",
+		English:  "The host left the room",
+		Japanese: "ホストが退出しました",
+	},
+
+	// Game progress
+	"game.revealing": {
+		Korean:   "모든 라운드가 종료되었습니다. 역할 공개 단계로 이동합니다.",
+		English:  "All rounds are over. Moving on to the role reveal.",
+		Japanese: "すべてのラウンドが終了しました。役職公開に移ります。",
+	},
+	"game.revealing.early_end": {
+		Korean:   "게임이 즉시 종료되었습니다. 역할 공개 단계로 이동합니다.",
+		English:  "The game ended instantly. Moving on to the role reveal.",
+		Japanese: "ゲームが即座に終了しました。役職公開に移ります。",
+	},
+
+	// Conditions changed by another player
+	"conditions.power_used": {
+		Korean:   "%s의 능력이 사용되었습니다",
+		English:  "%s used their power on you",
+		Japanese: "%sの能力が使われました",
+	},
+	"conditions.cured": {
+		Korean:   "%s에게 치료받았습니다",
+		English:  "You were cured by %s",
+		Japanese: "%sに治療されました",
+	},
+	"conditions.card_shared": {
+		Korean:   "%s와(과) 카드를 공유했습니다",
+		English:  "You card shared with %s",
+		Japanese: "%sとカードを共有しました",
+	},
+
+	// Team results
+	"outcome.zombies_won": {
+		Korean:   "살아남은 모든 플레이어가 좀비가 되어 좀비 팀이 승리했습니다",
+		English:  "Every surviving player became a zombie, so Team Zombie won",
+		Japanese: "生き残った全員がゾンビになったため、ゾンビチームが勝利しました",
+	},
+	"outcome.zombies_lost": {
+		Korean:   "좀비가 되지 않은 플레이어가 살아남았습니다",
+		English:  "A player who was not a zombie survived",
+		Japanese: "ゾンビにならなかったプレイヤーが生き残りました",
+	},
+	"outcome.red_won": {
+		Korean:   "대통령이 사망하여 레드 팀이 승리했습니다",
+		English:  "The President died, so Red Team won",
+		Japanese: "大統領が死亡したため、レッドチームが勝利しました",
+	},
+	"outcome.blue_won": {
+		Korean:   "대통령이 생존하여 블루 팀이 승리했습니다",
+		English:  "The President survived, so Blue Team won",
+		Japanese: "大統領が生き残ったため、ブルーチームが勝利しました",
+	},
+	"outcome.no_role": {
+		Korean:   "역할이 배정되지 않았습니다",
+		English:  "No role was assigned",
+		Japanese: "役職が配られませんでした",
+	},
+	"outcome.not_judged": {
+		Korean:   "이 역할의 승리 조건은 자동으로 판정되지 않습니다",
+		English:  "This role's win condition is not judged automatically",
+		Japanese: "この役職の勝利条件は自動では判定されません",
+	},
+
+	// Relationships (Cupid, Eris)
+	"outcome.love.apart": {
+		Korean:   "사랑에 빠진 %s와(과) 다른 방에서 게임을 마쳤습니다",
+		English:  "Ended the game in a different room from %s, who they are in love with",
+		Japanese: "恋に落ちた%sと別の部屋でゲームを終えました",
+	},
+	"outcome.hate.together": {
+		Korean:   "증오하는 %s와(과) 같은 방에서 게임을 마쳤습니다",
+		English:  "Ended the game in the same room as %s, who they are in hate with",
+		Japanese: "憎み合う%sと同じ部屋でゲームを終えました",
+	},
+
+	// Goal binding (Clone, Robot)
+	"outcome.binding.cycle": {
+		Korean:   "승리 조건이 서로를 참조하여 판정할 수 없습니다",
+		English:  "The win conditions refer to each other and cannot be judged",
+		Japanese: "勝利条件が互いを参照しているため判定できません",
+	},
+	"outcome.binding.no_partner": {
+		Korean:   "카드나 색을 공유한 플레이어가 없어 승리 조건이 정해지지 않았습니다",
+		English:  "Never card or color shared with anyone, so no win condition was set",
+		Japanese: "カードや色を共有した相手がいないため、勝利条件が決まりませんでした",
+	},
+	"outcome.binding.partner_won": {
+		Korean:   "%s이(가) 승리하여 함께 승리했습니다",
+		English:  "%s won, so they won too",
+		Japanese: "%sが勝利したため、一緒に勝利しました",
+	},
+	"outcome.binding.partner_lost": {
+		Korean:   "%s이(가) 패배했습니다 (%s)",
+		English:  "%s lost (%s)",
+		Japanese: "%sが敗北しました（%s）",
+	},
+	"outcome.binding.partner_failed": {
+		Korean:   "%s이(가) 패배하여 승리했습니다 (%s)",
+		English:  "%s lost, so they won (%s)",
+		Japanese: "%sが敗北したため、勝利しました（%s）",
+	},
+	"outcome.binding.partner_succeeded": {
+		Korean:   "%s이(가) 승리하여 패배했습니다",
+		English:  "%s won, so they lost",
+		Japanese: "%sが勝利したため、敗北しました",
+	},
+	"outcome.binding.borrowed_goal": {
+		Korean:   "%s의 승리 조건을 따릅니다: %s",
+		English:  "Follows the win condition of %s: %s",
+		Japanese: "%sの勝利条件に従います: %s",
+	},
+	"outcome.binding.goal_unset": {
+		Korean:   "%s의 승리 조건이 정해지지 않았습니다",
+		English:  "%s has no win condition",
+		Japanese: "%sの勝利条件が決まっていません",
+	},
+	"outcome.binding.unknown": {
+		Korean:   "알 수 없는 승리 조건 연결 방식입니다",
+		English:  "Unknown goal binding",
+		Japanese: "不明な勝利条件の連結方法です",
+	},
+
+	// Grey roles
+	"outcome.role_missing": {
+		Korean:   "%s이(가) 게임에 없습니다",
+		English:  "%s is not in the game",
+		Japanese: "%sはゲームにいません",
+	},
+	"outcome.same_room": {
+		Korean:   "%s와(과) 같은 방에서 게임을 마쳤습니다",
+		English:  "Ended the game in the same room as %s",
+		Japanese: "%sと同じ部屋でゲームを終えました",
+	},
+	"outcome.different_room": {
+		Korean:   "%s와(과) 다른 방에서 게임을 마쳤습니다",
+		English:  "Ended the game in a different room from %s",
+		Japanese: "%sと別の部屋でゲームを終えました",
+	},
+	"outcome.bomber_missing": {
+		Korean:   "폭파범이 게임에 없습니다",
+		English:  "The Bomber is not in the game",
+		Japanese: "爆弾魔はゲームにいません",
+	},
+	"outcome.partner_away_from_bomber": {
+		Korean:   "%s이(가) 폭파범과 다른 방에서 게임을 마쳤습니다",
+		English:  "%s ended the game in a different room from the Bomber",
+		Japanese: "%sは爆弾魔と別の部屋でゲームを終えました",
+	},
+	"outcome.with_bomber": {
+		Korean:   "폭파범과 같은 방에서 게임을 마쳤습니다",
+		English:  "Ended the game in the same room as the Bomber",
+		Japanese: "爆弾魔と同じ部屋でゲームを終えました",
+	},
+	"outcome.partner_with_bomber": {
+		Korean:   "%s은(는) 폭파범과 같은 방에, 자신은 다른 방에 있습니다",
+		English:  "%s is in the Bomber's room, and they are not",
+		Japanese: "%sは爆弾魔と同じ部屋に、自分は別の部屋にいます",
+	},
+	"outcome.sniper.missing": {
+		Korean:   "스나이퍼가 게임에 없습니다",
+		English:  "The Sniper is not in the game",
+		Japanese: "スナイパーはゲームにいません",
+	},
+	"outcome.sniper.no_shot": {
+		Korean:   "스나이퍼가 아무도 쏘지 않았습니다",
+		English:  "The Sniper shot no one",
+		Japanese: "スナイパーは誰も撃ちませんでした",
+	},
+	"outcome.sniper.hit": {
+		Korean:   "스나이퍼가 %s(%s)을(를) 쐈습니다",
+		English:  "The Sniper shot %s (%s)",
+		Japanese: "スナイパーが%s（%s）を撃ちました",
+	},
+	"outcome.sniper.shot": {
+		Korean:   "스나이퍼가 %s을(를) 쐈습니다",
+		English:  "The Sniper shot %s",
+		Japanese: "スナイパーが%sを撃ちました",
+	},
+	"outcome.prediction.none": {
+		Korean:   "승리 팀을 예측하지 않았습니다",
+		English:  "Did not predict the winning team",
+		Japanese: "勝利チームを予想しませんでした",
+	},
+	"outcome.prediction.right": {
+		Korean:   "%s 팀의 승리를 맞혔습니다",
+		English:  "Correctly predicted a %s Team win",
+		Japanese: "%sチームの勝利を的中させました",
+	},
+	"outcome.prediction.wrong": {
+		Korean:   "%s 팀의 승리를 예측했지만 틀렸습니다",
+		English:  "Predicted a %s Team win, but was wrong",
+		Japanese: "%sチームの勝利を予想しましたが、外れました",
+	},
+	"outcome.prediction.neither_right": {
+		Korean:   "레드 팀과 블루 팀 모두 승리하지 못할 것을 맞혔습니다",
+		English:  "Correctly predicted that neither Red nor Blue Team would win",
+		Japanese: "レッドチームもブルーチームも勝たないことを的中させました",
+	},
+	"outcome.prediction.neither_wrong": {
+		Korean:   "레드 팀과 블루 팀 모두 승리하지 못할 것으로 예측했지만 틀렸습니다",
+		English:  "Predicted that neither Red nor Blue Team would win, but was wrong",
+		Japanese: "レッドチームもブルーチームも勝たないと予想しましたが、外れました",
+	},
+	"outcome.left_starting_room": {
+		Korean:   "라운드 %d에 처음 방을 떠났습니다",
+		English:  "Left the starting room in round %d",
+		Japanese: "ラウンド%dで最初の部屋を離れました",
+	},
+	"outcome.stayed_in_starting_room": {
+		Korean:   "처음 방을 한 번도 떠나지 않았습니다",
+		English:  "Never left the starting room",
+		Japanese: "最初の部屋を一度も離れませんでした",
+	},
+	"outcome.hostage_rounds": {
+		Korean:   "%d개 라운드에서 인질로 보내졌습니다 (필요: %d)",
+		English:  "Sent as a hostage in %d round(s) (needed: %d)",
+		Japanese: "%dラウンドで人質として送られました（必要: %d）",
+	},
+	"outcome.not_leader_at_end": {
+		Korean:   "게임이 끝날 때 리더가 아니었습니다",
+		English:  "Was not a leader at the end of the game",
+		Japanese: "ゲーム終了時にリーダーではありませんでした",
+	},
+	"outcome.led_both_rooms": {
+		Korean:   "두 방의 리더를 모두 맡았습니다",
+		English:  "Led both rooms",
+		Japanese: "両方の部屋のリーダーを務めました",
+	},
+	"outcome.never_led_other_room": {
+		Korean:   "상대 방의 리더를 맡은 적이 없습니다",
+		English:  "Never led the other room",
+		Japanese: "相手の部屋のリーダーを務めたことがありません",
+	},
+	"outcome.not_card_shared": {
+		Korean:   "%s와(과) 카드를 공유하지 않았습니다",
+		English:  "Did not card share with %s",
+		Japanese: "%sとカードを共有しませんでした",
+	},
+	"outcome.card_shared_all": {
+		Korean:   "필요한 모든 역할과 카드를 공유했습니다",
+		English:  "Card shared with every required role",
+		Japanese: "必要なすべての役職とカードを共有しました",
+	},
+	"outcome.hot_potato": {
+		Korean:   "핫 포테이토 카드를 가진 채 게임을 마쳤습니다",
+		English:  "Ended the game holding the Hot Potato card",
+		Japanese: "ホットポテトのカードを持ったままゲームを終えました",
+	},
+	"outcome.leprechaun": {
+		Korean:   "레프리콘 카드를 가진 채 게임을 마쳤습니다",
+		English:  "Ended the game holding the Leprechaun card",
+		Japanese: "レプラコーンのカードを持ったままゲームを終えました",
+	},
+	"outcome.rival.missing": {
+		Korean:   "경쟁하는 %s이(가) 게임에 없습니다",
+		English:  "The rival %s is not in the game",
+		Japanese: "競争相手の%sはゲームにいません",
+	},
+	"outcome.rival.met": {
+		Korean:   "%s도 같은 조건을 달성했습니다",
+		English:  "%s met the same condition",
+		Japanese: "%sも同じ条件を達成しました",
+	},
+	"outcome.rival.not_met": {
+		Korean:   "%s은(는) 조건을 달성하지 못했습니다",
+		English:  "%s did not meet the condition",
+		Japanese: "%sは条件を達成できませんでした",
+	},
+
+	// winCondition expressions
+	"outcome.expression.invalid": {
+		Korean:   "승리 조건 식이 올바르지 않습니다",
+		English:  "The win condition expression is invalid",
+		Japanese: "勝利条件の式が正しくありません",
+	},
+	"outcome.expression.failed": {
+		Korean:   "승리 조건을 판정할 수 없습니다",
+		English:  "The win condition could not be judged",
+		Japanese: "勝利条件を判定できません",
+	},
+	"outcome.expression.met": {
+		Korean:   "승리 조건을 달성했습니다",
+		English:  "Met the win condition",
+		Japanese: "勝利条件を達成しました",
+	},
+	"outcome.expression.not_met": {
+		Korean:   "승리 조건을 달성하지 못했습니다",
+		English:  "Did not meet the win condition",
+		Japanese: "勝利条件を達成できませんでした",
+	},
+}
+
+// HasMessage reports whether a message ID is in the catalog
+func HasMessage(id string) bool {
+	_, ok := catalog[id]
+	return ok
+}
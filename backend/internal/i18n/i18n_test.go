@@ -0,0 +1,121 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		requested      string
+		acceptLanguage string
+		want           string
+	}{
+		{"nothing asked", "", "", DefaultLocale},
+		{"requested locale wins", "ja", "en-US,en;q=0.9", Japanese},
+		{"requested tag is normalized", "EN_gb", "", English},
+		{"unsupported request falls back to the header", "fr", "ja-JP", Japanese},
+		{"first supported header language", "", "fr-FR,ja;q=0.8,en;q=0.7", Japanese},
+		{"highest weight wins", "", "en;q=0.5,ko;q=0.9", Korean},
+		{"equal weights keep header order", "", "en,ja", English},
+		{"zero weight is refused", "", "ja;q=0,en;q=0.1", English},
+		{"malformed weight is skipped", "", "ja;q=x,en", English},
+		{"no supported language", "", "fr,de;q=0.5,*", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.requested, tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.requested, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText_Get(t *testing.T) {
+	text := Text{English: "President", Korean: "대통령"}
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{Korean, "대통령"},
+		{English, "President"},
+		{Japanese, "President"}, // Falls back to English first
+		{"fr", "President"},
+		{"", "대통령"}, // DefaultLocale
+	}
+
+	for _, tt := range tests {
+		if got := text.Get(tt.locale); got != tt.want {
+			t.Errorf("Get(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+	if got := (Text{}).Get(Korean); got != "" {
+		t.Errorf("Get on empty text = %q, want \"\"", got)
+	}
+}
+
+func TestText_With(t *testing.T) {
+	names := Text{Japanese: "大統領", Korean: "대통령"}
+	merged := names.With(English, "President").With(Korean, "다른 이름").With(Japanese, "")
+
+	want := Text{English: "President", Korean: "대통령", Japanese: "大統領"}
+	if fmt.Sprint(merged) != fmt.Sprint(want) {
+		t.Errorf("With() = %v, want %v", merged, want)
+	}
+	if len(names) != 2 {
+		t.Errorf("With() changed the original text: %v", names)
+	}
+}
+
+func TestMessage_Render(t *testing.T) {
+	president := Text{English: "President", Korean: "대통령", Japanese: "大統領"}
+	bomber := Text{English: "Bomber", Korean: "폭파범", Japanese: "爆弾魔"}
+
+	tests := []struct {
+		name    string
+		message Message
+		locale  string
+		want    string
+	}{
+		{"plain", Msg("game.revealing"), English, "All rounds are over. Moving on to the role reveal."},
+		{"text argument", Msg("outcome.same_room", president), Japanese, "大統領と同じ部屋でゲームを終えました"},
+		{"list argument", Msg("outcome.same_room", List{president, bomber}), Japanese, "大統領、爆弾魔と同じ部屋でゲームを終えました"},
+		{"message argument", Msg("outcome.binding.partner_lost", "minji", Msg("outcome.red_won")), English, "minji lost (The President died, so Red Team won)"},
+		{"number argument", Msg("player.default_nickname", 3), Korean, "플레이어3"},
+		{"unknown locale", Msg("system.name"), "fr", "System"},
+		{"unknown message", Msg("no.such.message"), Korean, "no.such.message"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.message.Render(tt.locale); got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.locale, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCatalog_Complete checks every message is translated and takes the same arguments in every locale
+func TestCatalog_Complete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[a-z]`)
+	for id, text := range catalog {
+		want := fmt.Sprint(verbs.FindAllString(text[Korean], -1))
+		for _, locale := range Supported {
+			if text[locale] == "" {
+				t.Errorf("message %q has no %s text", id, locale)
+				continue
+			}
+			if got := fmt.Sprint(verbs.FindAllString(text[locale], -1)); got != want {
+				t.Errorf("message %q takes %s in %s, but %s in %s", id, got, locale, want, Korean)
+			}
+		}
+		for locale := range text {
+			if !IsSupported(locale) {
+				t.Errorf("message %q has text in unsupported locale %q", id, locale)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Package i18n holds the server's translated text: locale-keyed role text and a catalog of
+// the messages the server sends to players.
+//
+// Every player has a locale, negotiated when they join from a "locale" parameter or the
+// Accept-Language header. Text written in several languages (Text) and catalog messages
+// (Message) are rendered in the reader's locale, so one game can show Korean, English and
+// Japanese players their own language.
+//
+//	i18n.Negotiate("", "ja-JP,ja;q=0.9,en;q=0.8") // "ja"
+//	i18n.Msg("game.revealing").Render("en")        // "All rounds are over. ..."
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Supported locales
+const (
+	Korean   = "ko"
+	English  = "en"
+	Japanese = "ja"
+)
+
+// DefaultLocale is used when a player asks for no supported locale
+const DefaultLocale = Korean
+
+// Supported lists the locales the server has text for, in fallback order (see Text.Get)
+var Supported = []string{English, Korean, Japanese}
+
+// IsSupported reports whether a locale is one of Supported
+func IsSupported(locale string) bool {
+	for _, supported := range Supported {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalize returns the supported locale of a language tag ("ja-JP" -> "ja"), or "" if the
+// language is not supported
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if IsSupported(tag) {
+		return tag
+	}
+	return ""
+}
+
+// Negotiate picks a player's locale
+// An explicitly requested locale (such as a join parameter) wins; otherwise the
+// Accept-Language header is read by preference, and DefaultLocale is used if it names no
+// supported language.
+func Negotiate(requested, acceptLanguage string) string {
+	if locale := Normalize(requested); locale != "" {
+		return locale
+	}
+
+	type preference struct {
+		locale string
+		q      float64
+	}
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if locale := Normalize(tag); locale != "" && q > 0 {
+			preferences = append(preferences, preference{locale, q})
+		}
+	}
+
+	// Equal weights keep the header's order
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].q > preferences[j].q })
+	if len(preferences) > 0 {
+		return preferences[0].locale
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Text is one piece of text written in several languages, keyed by locale
+// Role names and descriptions in role configurations are Text:
+//
+//	"names": {"en": "President", "ko": "대통령", "ja": "大統領"}
+type Text map[string]string
+
+// Get returns the text in a locale, falling back to the Supported locales in order
+// An empty locale (a player who never negotiated one) reads as DefaultLocale. Returns ""
+// if the text is written in no supported locale.
+func (t Text) Get(locale string) string {
+	if locale == "" {
+		locale = DefaultLocale
+	}
+	if text := t[locale]; text != "" {
+		return text
+	}
+	for _, fallback := range Supported {
+		if text := t[fallback]; text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// With returns a copy of the text with a translation added, unless the locale already has one
+// Used to merge single-language fields (such as a role's nameKo) into a Text.
+func (t Text) With(locale, text string) Text {
+	merged := make(Text, len(t)+1)
+	for key, value := range t {
+		merged[key] = value
+	}
+	if merged[locale] == "" && text != "" {
+		merged[locale] = text
+	}
+	return merged
+}
+
+// Message is a catalog message and its arguments, rendered in each reader's locale
+// Arguments that are themselves Text, Message or List are rendered in the same locale.
+type Message struct {
+	ID   string
+	Args []any
+}
+
+// Msg builds a catalog message
+func Msg(id string, args ...any) Message {
+	return Message{ID: id, Args: args}
+}
+
+// List is a list of arguments joined with the locale's list separator
+type List []any
+
+// Render formats the message in a locale
+// A message missing from the catalog renders as its ID.
+func (m Message) Render(locale string) string {
+	format, ok := catalog[m.ID]
+	if !ok {
+		log.Printf("[WARN] Message %q is not in the catalog", m.ID)
+		return m.ID
+	}
+	if len(m.Args) == 0 {
+		return format.Get(locale)
+	}
+
+	args := make([]any, len(m.Args))
+	for i, arg := range m.Args {
+		args[i] = renderArg(arg, locale)
+	}
+	return fmt.Sprintf(format.Get(locale), args...)
+}
+
+// renderArg renders a translatable message argument; other arguments are left to fmt
+func renderArg(arg any, locale string) any {
+	switch arg := arg.(type) {
+	case Text:
+		return arg.Get(locale)
+	case Message:
+		return arg.Render(locale)
+	case List:
+		items := make([]string, len(arg))
+		for i, item := range arg {
+			items[i] = fmt.Sprint(renderArg(item, locale))
+		}
+		return strings.Join(items, Msg("list.separator").Render(locale))
+	}
+	return arg
+}
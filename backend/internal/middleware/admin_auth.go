@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth returns a Gin middleware that requires "Authorization: Bearer <token>"
+// With an empty token the protected routes are disabled.
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"code":    "ADMIN_API_DISABLED",
+				"message": "Admin API is disabled; set ROLE_ADMIN_TOKEN to enable it",
+			})
+			c.Abort()
+			return
+		}
+
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"code":    "UNAUTHORIZED",
+				"message": "Valid admin token required",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
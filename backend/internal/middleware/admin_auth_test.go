@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		wantStatus    int
+	}{
+		{"Valid token", "secret", "Bearer secret", http.StatusOK},
+		{"Wrong token", "secret", "Bearer wrong", http.StatusUnauthorized},
+		{"Missing header", "secret", "", http.StatusUnauthorized},
+		{"Missing Bearer prefix", "secret", "secret", http.StatusUnauthorized},
+		{"Disabled without a token", "", "Bearer ", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/admin", AdminAuth(tt.token), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/admin", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// CardTransfer records a character card changing hands mid-game (Hot Potato, Leprechaun)
+type CardTransfer struct {
+	RoleID        string    `json:"roleId"`            // Card that moved
+	FromPlayerID  string    `json:"fromPlayerId"`      // Previous holder
+	ToPlayerID    string    `json:"toPlayerId"`        // New holder
+	ShareID       string    `json:"shareId,omitempty"` // Card share that caused the swap
+	RoundNumber   int       `json:"roundNumber"`       // Round the swap happened in
+	TransferredAt time.Time `json:"transferredAt"`
+}
+
+// CardHistory returns the transfers of a single card in the order they happened
+func (gs *GameSession) CardHistory(roleID string) []*CardTransfer {
+	var history []*CardTransfer
+	for _, transfer := range gs.CardTransfers {
+		if transfer.RoleID == roleID {
+			history = append(history, transfer)
+		}
+	}
+	return history
+}
+
+// OriginalHolder returns the player who was dealt the card, given its current holder
+func (gs *GameSession) OriginalHolder(roleID, currentHolderID string) string {
+	history := gs.CardHistory(roleID)
+	if len(history) == 0 {
+		return currentHolderID
+	}
+	return history[0].FromPlayerID
+}
+
+// BuriedCard stands in for a player ID when a card moves to or from the buried pile (Drunk)
+const BuriedCard = "BURIED"
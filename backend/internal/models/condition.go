@@ -0,0 +1,143 @@
+package models
+
+import "time"
+
+// Condition represents a status effect that changes how a player plays or wins
+type Condition string
+
+const (
+	ConditionInLove Condition = "IN_LOVE" // Must end in the same room as their lover (Cupid)
+	ConditionInHate Condition = "IN_HATE" // Must end in the opposite room from their enemy (Eris)
+
+	// Psych conditions restrict sharing and can be cured by a Psychologist
+	ConditionShy      Condition = "SHY"      // Cannot reveal their card or color to anyone
+	ConditionCoy      Condition = "COY"      // May only color share
+	ConditionSavvy    Condition = "SAVVY"    // May only card share (Negotiator)
+	ConditionParanoid Condition = "PARANOID" // May card share only once per game
+	ConditionFoolish  Condition = "FOOLISH"  // Cannot refuse a share request
+
+	ConditionCursed Condition = "CURSED" // May only speak in "ahh" sounds (Mummy)
+)
+
+// psychConditions lists the conditions a Psychologist can cure
+var psychConditions = map[Condition]bool{
+	ConditionShy:      true,
+	ConditionCoy:      true,
+	ConditionSavvy:    true,
+	ConditionParanoid: true,
+	ConditionFoolish:  true,
+}
+
+// IsPsych reports whether the condition is a psych condition
+func (c Condition) IsPsych() bool {
+	return psychConditions[c]
+}
+
+// cancellingConditions lists conditions that cancel one another when a player holds both
+var cancellingConditions = map[Condition]Condition{
+	ConditionInLove: ConditionInHate,
+	ConditionInHate: ConditionInLove,
+}
+
+// HasCondition reports whether the player currently holds the condition
+func (p *Player) HasCondition(condition Condition) bool {
+	for _, c := range p.Conditions {
+		if c == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveCondition removes the condition from the player if present
+func (p *Player) RemoveCondition(condition Condition) {
+	for i, c := range p.Conditions {
+		if c == condition {
+			p.Conditions = append(p.Conditions[:i], p.Conditions[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddCondition gives the player a condition, applying cancellation rules
+// If the player already holds a cancelling condition, both are removed.
+// Returns false if the condition was cancelled instead of added.
+func (p *Player) AddCondition(condition Condition) bool {
+	if opposite, ok := cancellingConditions[condition]; ok && p.HasCondition(opposite) {
+		p.RemoveCondition(opposite)
+		return false
+	}
+	if !p.HasCondition(condition) {
+		p.Conditions = append(p.Conditions, condition)
+	}
+	return true
+}
+
+// Cleanse drops every acquired condition, leaving only those printed on the player's card
+// Applied whenever a player receives a new card.
+func (p *Player) Cleanse() {
+	p.Conditions = nil
+	if p.Role != nil {
+		p.Conditions = append(p.Conditions, p.Role.Conditions...)
+	}
+}
+
+// CurePsychConditions removes every psych condition from the player
+// Returns the conditions that were removed.
+func (p *Player) CurePsychConditions() []Condition {
+	var cured []Condition
+	remaining := p.Conditions[:0]
+	for _, c := range p.Conditions {
+		if c.IsPsych() {
+			cured = append(cured, c)
+			continue
+		}
+		remaining = append(remaining, c)
+	}
+	p.Conditions = remaining
+	return cured
+}
+
+// CardShareEffect changes the conditions of whoever card shares with the role's holder
+type CardShareEffect struct {
+	GiveConditions  []Condition `json:"giveConditions,omitempty"`  // Conditions the partner gains (e.g. Dealer gives FOOLISH)
+	ClearConditions bool        `json:"clearConditions,omitempty"` // Partner loses every condition first (Medic)
+	SwapCards       bool        `json:"swapCards,omitempty"`       // Holder and partner exchange cards on any share (Hot Potato, Leprechaun)
+}
+
+// Apply applies the effect to the partner and reports whether their conditions changed
+func (e *CardShareEffect) Apply(partner *Player) bool {
+	changed := false
+	if e.ClearConditions && len(partner.Conditions) > 0 {
+		partner.Conditions = nil
+		changed = true
+	}
+	for _, condition := range e.GiveConditions {
+		if partner.HasCondition(condition) {
+			continue
+		}
+		partner.AddCondition(condition)
+		changed = true
+	}
+	return changed
+}
+
+// Relationship links two players through a paired condition (in love, in hate)
+type Relationship struct {
+	Condition    Condition `json:"condition"`    // IN_LOVE or IN_HATE
+	PlayerIDs    [2]string `json:"playerIds"`    // The two linked players
+	SourceID     string    `json:"sourceId"`     // Player whose power created the link
+	SourceRoleID string    `json:"sourceRoleId"` // Role that created the link (CUPID, ERIS)
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// PartnerOf returns the other player in the relationship, or "" if the player is not part of it
+func (r *Relationship) PartnerOf(playerID string) string {
+	switch playerID {
+	case r.PlayerIDs[0]:
+		return r.PlayerIDs[1]
+	case r.PlayerIDs[1]:
+		return r.PlayerIDs[0]
+	}
+	return ""
+}
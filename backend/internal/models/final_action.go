@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// FinalActionType identifies an end-of-game choice made after round 3
+type FinalActionType string
+
+const (
+	FinalActionShoot   FinalActionType = "SHOOT"   // Choose a player to shoot (Sniper)
+	FinalActionPredict FinalActionType = "PREDICT" // Predict the winning team (Gambler)
+)
+
+// FinalAction records a single player's end-of-game choice
+type FinalAction struct {
+	PlayerID    string          `json:"playerId"`
+	RoleID      string          `json:"roleId"`               // Role that granted the action
+	Type        FinalActionType `json:"type"`                 // SHOOT or PREDICT
+	TargetID    string          `json:"targetId,omitempty"`   // Chosen player (SHOOT)
+	Prediction  TeamColor       `json:"prediction,omitempty"` // Predicted winner (PREDICT)
+	SubmittedAt time.Time       `json:"submittedAt"`
+}
+
+// FinalActionsPhase holds the state of the post-round-3 choice phase
+// Which players act and what they chose stay private until the reveal.
+type FinalActionsPhase struct {
+	Deadline time.Time               `json:"deadline"` // Unsubmitted choices are skipped after this
+	Pending  map[string]bool         `json:"-"`        // Player IDs still expected to act
+	Actions  map[string]*FinalAction `json:"-"`        // Submitted choices by player ID
+}
+
+// Complete reports whether every expected player has submitted
+func (p *FinalActionsPhase) Complete() bool {
+	return len(p.Pending) == 0
+}
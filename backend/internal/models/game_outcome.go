@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+)
+
+// GameOutcome represents the resolved result of a finished game
+type GameOutcome struct {
+	WinningTeam    TeamColor           `json:"winningTeam"`             // RED, BLUE or ZOMBIE
+	PresidentDead  bool                `json:"presidentDead"`           // Whether the President gained the "dead" condition
+	BomberRoom     RoomColor           `json:"bomberRoom"`              // Room the Bomber ended the game in
+	DetonationRoom RoomColor           `json:"detonationRoom"`          // Room whose players gained "dead"
+	EarlyEnd       *EarlyEnd           `json:"earlyEnd,omitempty"`      // Set if a power ended the game before round 3 finished
+	Players        []*PlayerOutcome    `json:"players"`                 // Per-player results for the reveal
+	CardTransfers  []*CardTransfer     `json:"cardTransfers,omitempty"` // Cards that changed hands during the game
+	FinalActions   []*FinalAction      `json:"finalActions,omitempty"`  // End-of-game choices, revealed with the outcome
+	BuriedRole     *Role               `json:"buriedRole,omitempty"`    // Card set aside at the start, if one was buried
+	LeaderHistory  []*LeadershipChange `json:"leaderHistory,omitempty"` // Who led which room, and when
+	ResolvedAt     time.Time           `json:"resolvedAt"`              // Resolution timestamp
+}
+
+// EarlyEnd reasons
+const (
+	EarlyEndBoom = "BOOM" // Dr. Boom card shared with the President
+)
+
+// EarlyEnd records a game that ended instantly instead of after round 3
+type EarlyEnd struct {
+	Reason      string    `json:"reason"`      // Why the game ended (e.g. BOOM)
+	TriggeredBy string    `json:"triggeredBy"` // Player whose power ended the game
+	RoleID      string    `json:"roleId"`      // Role of that player
+	Room        RoomColor `json:"room"`        // Room whose players gain the "dead" condition
+	EndedAt     time.Time `json:"endedAt"`
+}
+
+// PlayerOutcome represents a single player's result in the final reveal
+type PlayerOutcome struct {
+	PlayerID    string       `json:"playerId"`
+	Nickname    string       `json:"nickname"`
+	Role        *Role        `json:"role"`
+	Team        TeamColor    `json:"team"`
+	FinalRoom   RoomColor    `json:"finalRoom"`
+	Dead        bool         `json:"dead"`                  // Gained the "dead" condition
+	Won         bool         `json:"won"`                   // Whether the player achieved their win condition
+	Reason      string       `json:"reason"`                // Human readable explanation shown in the reveal (see GameOutcome.Localized)
+	ReasonText  i18n.Message `json:"-"`                     // Reason as a catalog message, rendered in each player's locale
+	Conditions  []Condition  `json:"conditions,omitempty"`  // Conditions held at the end of the game
+	RoomHistory []*RoomMove  `json:"roomHistory,omitempty"` // Starting room and every move during the game
+
+	// Goal binding (Clone, Robot): the player whose outcome or goal was copied
+	BoundToPlayerID string `json:"boundToPlayerId,omitempty"`
+	BoundToNickname string `json:"boundToNickname,omitempty"`
+}
+
+// Localized returns a copy of the outcome with every player's reason in the given locale
+func (o *GameOutcome) Localized(locale string) *GameOutcome {
+	localized := *o
+	localized.Players = make([]*PlayerOutcome, len(o.Players))
+	for i, player := range o.Players {
+		copied := *player
+		if copied.ReasonText.ID != "" {
+			copied.Reason = copied.ReasonText.Render(locale)
+		}
+		localized.Players[i] = &copied
+	}
+	return &localized
+}
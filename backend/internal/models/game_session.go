@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+)
 
 // GameSession represents active game state
 type GameSession struct {
@@ -13,19 +17,65 @@ type GameSession struct {
 	StartedAt       time.Time   `json:"startedAt"`       // Game start time
 	CurrentRound    int         `json:"currentRound"`    // Current round number (1, 2, 3)
 	RoundState      *RoundState `json:"roundState,omitempty"` // Current round state
+	Outcome         *GameOutcome `json:"outcome,omitempty"`   // Resolved result (set when revealing)
+	Shares          []*ShareRecord `json:"-"`                 // Card/color share history (private until reveal)
+	Relationships   []*Relationship `json:"-"`                // In love / in hate links created by powers
+	UsedPowers      map[string]bool `json:"-"`                // Player IDs that used their once-per-game power
+	EarlyEnd        *EarlyEnd       `json:"earlyEnd,omitempty"` // Set when a power ends the game instantly
+	CardTransfers   []*CardTransfer `json:"-"`                  // Character cards that changed hands (private until reveal)
+	FinalActions    *FinalActionsPhase `json:"finalActions,omitempty"` // Post-round-3 choices (Sniper, Gambler)
+	BuriedRole      *Role              `json:"-"`                      // Extra card dealt face down (hidden until reveal)
+	RoomHistory     []*RoomMove        `json:"-"`                      // Starting rooms and every move since (private until reveal)
+	LeaderHistory   []*LeadershipChange `json:"-"`                     // Every leader assignment and change (private until reveal)
+	RoleAdjustments []*RoleCountChange  `json:"roleAdjustments,omitempty"` // Changes auto-fix made to the role selection, shown to everyone
+}
+
+// CompletedShares returns accepted shares in the order they happened
+func (gs *GameSession) CompletedShares() []*ShareRecord {
+	var completed []*ShareRecord
+	for _, share := range gs.Shares {
+		if share.Status == ShareStatusAccepted {
+			completed = append(completed, share)
+		}
+	}
+	return completed
 }
 
 // Role represents a player's assigned role
 type Role struct {
-	ID            string    `json:"id"`                      // Role identifier
-	Name          string    `json:"name"`                    // English display name
-	NameKo        string    `json:"nameKo,omitempty"`        // Korean display name
-	Description   string    `json:"description"`             // Role description (English)
-	DescriptionKo string    `json:"descriptionKo,omitempty"` // Role description (Korean)
-	Team          TeamColor `json:"team"`                    // Team affiliation
-	Icon          string    `json:"icon,omitempty"`          // Emoji icon for role
-	IsSpy         bool      `json:"isSpy"`                   // Spy flag
-	IsLeader      bool      `json:"isLeader"`                // Leader flag (President/Bomber)
+	ID              string           `json:"id"`                        // Role identifier
+	Name            string           `json:"name"`                      // English display name
+	NameKo          string           `json:"nameKo,omitempty"`          // Korean display name
+	Description     string           `json:"description"`               // Role description (English)
+	DescriptionKo   string           `json:"descriptionKo,omitempty"`   // Role description (Korean)
+	Names           i18n.Text        `json:"names,omitempty"`           // Display name by locale
+	Descriptions    i18n.Text        `json:"descriptions,omitempty"`    // Role description by locale
+	Team            TeamColor        `json:"team"`                      // Team affiliation
+	Icon            string           `json:"icon,omitempty"`            // Emoji icon for role
+	IsSpy           bool             `json:"isSpy"`                     // Spy flag
+	IsLeader        bool             `json:"isLeader"`                  // Leader flag (President/Bomber)
+	GoalBinding     string           `json:"goalBinding,omitempty"`     // Goal bound to first card or color share partner ("outcome", "goal" or "opposite")
+	Power           string           `json:"power,omitempty"`           // Activated power (e.g. CUPID, ERIS)
+	Conditions      []Condition      `json:"conditions,omitempty"`      // Conditions the role starts the game with (e.g. SHY)
+	OnCardShare     *CardShareEffect `json:"onCardShare,omitempty"`     // Conditions applied to card share partners (e.g. Dealer)
+	FinalAction     FinalActionType  `json:"finalAction,omitempty"`     // End-of-game choice the role makes (e.g. SHOOT)
+	BackupFor       string           `json:"backupFor,omitempty"`       // Role this role stands in for while its card is buried (e.g. BOMBER)
+	SwapBuriedRound int              `json:"swapBuriedRound,omitempty"` // Round at whose start the holder swaps with the buried card (Drunk)
+	WinCondition    *WinCondition    `json:"winCondition,omitempty"`    // Declarative room goal (e.g. Butler, Wife)
+}
+
+// NameText returns the role's name in every locale it is written in, or its ID if it has no name
+func (r *Role) NameText() i18n.Text {
+	names := r.Names.With(i18n.English, r.Name).With(i18n.Korean, r.NameKo)
+	if len(names) == 0 {
+		return i18n.Text{i18n.English: r.ID}
+	}
+	return names
+}
+
+// DescriptionText returns the role's description in every locale it is written in
+func (r *Role) DescriptionText() i18n.Text {
+	return r.Descriptions.With(i18n.English, r.Description).With(i18n.Korean, r.DescriptionKo)
 }
 
 // Predefined roles
@@ -37,6 +87,12 @@ var (
 		Team:        TeamBlue,
 		IsSpy:       false,
 		IsLeader:    true,
+		Names:       i18n.Text{i18n.English: "President", i18n.Korean: "대통령", i18n.Japanese: "大統領"},
+		Descriptions: i18n.Text{
+			i18n.English:  "Leader of the Blue Team. The Blue Team wins if the President ends in a different room from the Bomber.",
+			i18n.Korean:   "블루 팀의 리더. 블루 팀이 승리하려면 폭파범과 다른 방에 있어야 합니다.",
+			i18n.Japanese: "ブルーチームのリーダー。ブルーチームが勝つには爆弾魔と別の部屋にいる必要があります。",
+		},
 	}
 
 	RoleBomber = Role{
@@ -46,6 +102,12 @@ var (
 		Team:        TeamRed,
 		IsSpy:       false,
 		IsLeader:    true,
+		Names:       i18n.Text{i18n.English: "Bomber", i18n.Korean: "폭파범", i18n.Japanese: "爆弾魔"},
+		Descriptions: i18n.Text{
+			i18n.English:  "Leader of the Red Team. The Red Team wins if the Bomber ends in the same room as the President.",
+			i18n.Korean:   "레드 팀의 리더. 레드 팀이 승리하려면 대통령과 같은 방에 있어야 합니다.",
+			i18n.Japanese: "レッドチームのリーダー。レッドチームが勝つには大統領と同じ部屋にいる必要があります。",
+		},
 	}
 
 	RoleRedSpy = Role{
@@ -55,6 +117,12 @@ var (
 		Team:        TeamRed,
 		IsSpy:       true,
 		IsLeader:    false,
+		Names:       i18n.Text{i18n.English: "Red Spy", i18n.Korean: "레드 팀 스파이", i18n.Japanese: "レッドスパイ"},
+		Descriptions: i18n.Text{
+			i18n.English:  "Red Team member. Appears as Blue Team in a color share; a card share reveals the spy.",
+			i18n.Korean:   "레드 팀 소속. 진영 정보 교환 시 블루 팀으로 보이며, 전체 정보 교환 시 스파이 신분이 공개됩니다.",
+			i18n.Japanese: "レッドチーム所属。カラー共有ではブルーチームに見え、カード共有でスパイの正体が明かされます。",
+		},
 	}
 
 	RoleBlueSpy = Role{
@@ -64,6 +132,12 @@ var (
 		Team:        TeamBlue,
 		IsSpy:       true,
 		IsLeader:    false,
+		Names:       i18n.Text{i18n.English: "Blue Spy", i18n.Korean: "블루 팀 스파이", i18n.Japanese: "ブルースパイ"},
+		Descriptions: i18n.Text{
+			i18n.English:  "Blue Team member. Appears as Red Team in a color share; a card share reveals the spy.",
+			i18n.Korean:   "블루 팀 소속. 진영 정보 교환 시 레드 팀으로 보이며, 전체 정보 교환 시 스파이 신분이 공개됩니다.",
+			i18n.Japanese: "ブルーチーム所属。カラー共有ではレッドチームに見え、カード共有でスパイの正体が明かされます。",
+		},
 	}
 
 	RoleRedOperative = Role{
@@ -73,6 +147,12 @@ var (
 		Team:        TeamRed,
 		IsSpy:       false,
 		IsLeader:    false,
+		Names:       i18n.Text{i18n.English: "Red Operative", i18n.Korean: "레드 팀 요원", i18n.Japanese: "レッドチーム隊員"},
+		Descriptions: i18n.Text{
+			i18n.English:  "An ordinary Red Team member.",
+			i18n.Korean:   "레드 팀의 일반 시민.",
+			i18n.Japanese: "レッドチームの一般市民。",
+		},
 	}
 
 	RoleBlueOperative = Role{
@@ -82,5 +162,11 @@ var (
 		Team:        TeamBlue,
 		IsSpy:       false,
 		IsLeader:    false,
+		Names:       i18n.Text{i18n.English: "Blue Operative", i18n.Korean: "블루 팀 요원", i18n.Japanese: "ブルーチーム隊員"},
+		Descriptions: i18n.Text{
+			i18n.English:  "An ordinary Blue Team member.",
+			i18n.Korean:   "블루 팀의 일반 시민.",
+			i18n.Japanese: "ブルーチームの一般市民。",
+		},
 	}
 )
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// LeadershipChange records a player becoming leader of a room (Mastermind)
+type LeadershipChange struct {
+	RoomColor        RoomColor              `json:"roomColor"`
+	LeaderID         string                 `json:"leaderId"`
+	PreviousLeaderID string                 `json:"previousLeaderId,omitempty"` // Empty for the first assignment
+	Reason           LeadershipChangeReason `json:"reason"`
+	RoundNumber      int                    `json:"roundNumber"`
+	ChangedAt        time.Time              `json:"changedAt"`
+}
+
+// RecordLeader makes a player leader of a room in the current round state and records the change
+// Re-electing the current leader is not recorded.
+func (gs *GameSession) RecordLeader(roomColor RoomColor, leaderID string, reason LeadershipChangeReason) {
+	roundState := gs.RoundState
+	previousID := roundState.RedLeaderID
+	if roomColor == BlueRoom {
+		previousID = roundState.BlueLeaderID
+	}
+	if previousID == leaderID {
+		return
+	}
+
+	if roomColor == RedRoom {
+		roundState.RedLeaderID = leaderID
+	} else {
+		roundState.BlueLeaderID = leaderID
+	}
+
+	gs.LeaderHistory = append(gs.LeaderHistory, &LeadershipChange{
+		RoomColor:        roomColor,
+		LeaderID:         leaderID,
+		PreviousLeaderID: previousID,
+		Reason:           reason,
+		RoundNumber:      roundState.RoundNumber,
+		ChangedAt:        time.Now(),
+	})
+}
+
+// LeaderRoomAtEnd returns the room the player leads in the current round state, or "" if none
+func (gs *GameSession) LeaderRoomAtEnd(playerID string) RoomColor {
+	if gs.RoundState == nil {
+		return ""
+	}
+	switch playerID {
+	case gs.RoundState.RedLeaderID:
+		return RedRoom
+	case gs.RoundState.BlueLeaderID:
+		return BlueRoom
+	}
+	return ""
+}
+
+// RoomsLedBy returns every room the player has led during the game
+func (gs *GameSession) RoomsLedBy(playerID string) map[RoomColor]bool {
+	rooms := make(map[RoomColor]bool)
+	for _, change := range gs.LeaderHistory {
+		if change.LeaderID == playerID {
+			rooms[change.RoomColor] = true
+		}
+	}
+	return rooms
+}
@@ -9,6 +9,13 @@ const (
 	TeamRed  TeamColor = "RED"
 	TeamBlue TeamColor = "BLUE"
 	TeamGrey TeamColor = "GREY"
+
+	// TeamZombie is contagious: anyone who shares with a zombie joins it,
+	// and it replaces the player's original win condition
+	TeamZombie TeamColor = "ZOMBIE"
+
+	// TeamNone names no team; the Gambler predicts it when neither Red nor Blue wins
+	TeamNone TeamColor = "NONE"
 )
 
 // RoomColor represents the physical room assignment
@@ -30,4 +37,6 @@ type Player struct {
 	Team        TeamColor  `json:"team"`        // RED or BLUE (empty before game start)
 	CurrentRoom RoomColor  `json:"currentRoom"` // RED_ROOM or BLUE_ROOM (empty before game start)
 	ConnectedAt time.Time  `json:"connectedAt"` // Join timestamp
+	Locale      string     `json:"locale"`      // Language the player reads server text in (e.g. "ko", "en", "ja")
+	Conditions  []Condition `json:"-"`          // Active conditions (private, revealed at game end)
 }
@@ -0,0 +1,9 @@
+package models
+
+// RoleCountChange is one change auto-fix made to the role selection before dealing
+type RoleCountChange struct {
+	RoleID string `json:"roleId"`
+	From   int    `json:"from"`   // Count before the fix (0 if the role was added)
+	To     int    `json:"to"`     // Count dealt (0 if the role was removed)
+	Reason string `json:"reason"` // Why the count was changed
+}
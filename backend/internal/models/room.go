@@ -1,27 +1,38 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // RoomStatus represents the room state
 type RoomStatus string
 
 const (
-	RoomStatusWaiting    RoomStatus = "WAITING"     // Lobby state
-	RoomStatusInProgress RoomStatus = "IN_PROGRESS" // Game in progress
-	RoomStatusRevealing  RoomStatus = "REVEALING"   // Role reveal phase
+	RoomStatusWaiting      RoomStatus = "WAITING"       // Lobby state
+	RoomStatusInProgress   RoomStatus = "IN_PROGRESS"   // Game in progress
+	RoomStatusFinalActions RoomStatus = "FINAL_ACTIONS" // End-of-game choices after round 3 (Sniper, Gambler)
+	RoomStatusRevealing    RoomStatus = "REVEALING"     // Role reveal phase
 )
 
 // Room represents a game session lobby
 type Room struct {
-	Code          string            `json:"code"`                   // 6-character unique code
-	Status        RoomStatus        `json:"status"`                 // Current status
-	Players       []*Player         `json:"players"`                // List of participants
-	MaxPlayers    int               `json:"maxPlayers"`             // Maximum capacity (6-30)
-	GameSession   *GameSession      `json:"gameSession"`            // Game state (nil in lobby)
-	IsPublic      bool              `json:"isPublic"`               // Room visibility (public/private)
-	RoleConfigID  string            `json:"roleConfigId,omitempty"` // Role configuration ID (default: "standard")
-	SelectedRoles map[string]int    `json:"selectedRoles,omitempty"` // Selected role IDs and their counts
-	HostNickname  string            `json:"hostNickname,omitempty"` // Host's display name (optional)
-	CreatedAt     time.Time         `json:"createdAt"`              // Creation timestamp
-	UpdatedAt     time.Time         `json:"updatedAt"`              // Last update timestamp
+	Code               string          `json:"code"`                         // 6-character unique code
+	Status             RoomStatus      `json:"status"`                       // Current status
+	Players            []*Player       `json:"players"`                      // List of participants
+	MaxPlayers         int             `json:"maxPlayers"`                   // Maximum capacity (6-30)
+	GameSession        *GameSession    `json:"gameSession"`                  // Game state (nil in lobby)
+	IsPublic           bool            `json:"isPublic"`                     // Room visibility (public/private)
+	RoleConfigID       string          `json:"roleConfigId,omitempty"`       // Role configuration ID (default: "standard")
+	RoleConfigRevision int             `json:"roleConfigRevision,omitempty"` // Revision of the role configuration when the room was created
+	SelectedRoles      map[string]int  `json:"selectedRoles,omitempty"`      // Selected role IDs and their counts
+	CustomRoleConfig   json.RawMessage `json:"customRoleConfig,omitempty"`   // Resolved inline role set used instead of RoleConfigID; discarded with the room
+	HostNickname       string          `json:"hostNickname,omitempty"`       // Host's display name (optional)
+	CreatedAt          time.Time       `json:"createdAt"`                    // Creation timestamp
+	UpdatedAt          time.Time       `json:"updatedAt"`                    // Last update timestamp
+}
+
+// IsGameActive reports whether a game is being played (rounds or end-of-game choices)
+func (r *Room) IsGameActive() bool {
+	return r.Status == RoomStatusInProgress || r.Status == RoomStatusFinalActions
 }
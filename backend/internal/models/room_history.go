@@ -0,0 +1,63 @@
+package models
+
+import "time"
+
+// MoveReason explains why a player entered a room
+type MoveReason string
+
+const (
+	MoveReasonStart   MoveReason = "START"   // Initial room from room assignment
+	MoveReasonHostage MoveReason = "HOSTAGE" // Sent to the other room in the hostage exchange
+	MoveReasonPower   MoveReason = "POWER"   // Moved by a role power
+)
+
+// RoomMove records a player entering a room (Agoraphobe, Traveler)
+type RoomMove struct {
+	PlayerID    string     `json:"playerId"`
+	FromRoom    RoomColor  `json:"fromRoom,omitempty"` // Empty for the starting room
+	ToRoom      RoomColor  `json:"toRoom"`
+	Reason      MoveReason `json:"reason"`
+	RoundNumber int        `json:"roundNumber"` // Round the move happened in (0 for the starting room)
+	MovedAt     time.Time  `json:"movedAt"`
+}
+
+// RecordStartingRooms adds each player's initial room to the room history
+func (gs *GameSession) RecordStartingRooms(players []*Player) {
+	now := time.Now()
+	for _, player := range players {
+		gs.RoomHistory = append(gs.RoomHistory, &RoomMove{
+			PlayerID: player.ID,
+			ToRoom:   player.CurrentRoom,
+			Reason:   MoveReasonStart,
+			MovedAt:  now,
+		})
+	}
+}
+
+// MovePlayer moves a player to another room and records the move
+// Moving a player into the room they are already in is not recorded.
+func (gs *GameSession) MovePlayer(player *Player, to RoomColor, reason MoveReason) {
+	if player.CurrentRoom == to {
+		return
+	}
+	gs.RoomHistory = append(gs.RoomHistory, &RoomMove{
+		PlayerID:    player.ID,
+		FromRoom:    player.CurrentRoom,
+		ToRoom:      to,
+		Reason:      reason,
+		RoundNumber: gs.CurrentRound,
+		MovedAt:     time.Now(),
+	})
+	player.CurrentRoom = to
+}
+
+// PlayerRoomHistory returns the rooms a player entered, in order
+func (gs *GameSession) PlayerRoomHistory(playerID string) []*RoomMove {
+	var history []*RoomMove
+	for _, move := range gs.RoomHistory {
+		if move.PlayerID == playerID {
+			history = append(history, move)
+		}
+	}
+	return history
+}
@@ -34,6 +34,7 @@ type RoundState struct {
 	BlueHostages    []string    `json:"blueHostages"`      // Blue room hostage player IDs
 	RedLeaderReady  bool        `json:"redLeaderReady"`    // Red leader ready for next round
 	BlueLeaderReady bool        `json:"blueLeaderReady"`   // Blue leader ready for next round
+	UsedPowers      map[string]bool `json:"-"`             // Player IDs that used their once-per-round power
 	StartedAt       time.Time   `json:"startedAt"`         // Round start time
 	EndedAt         *time.Time  `json:"endedAt,omitempty"` // Round end time
 }
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ShareType represents what a player reveals during a share
+type ShareType string
+
+const (
+	ShareTypeCard  ShareType = "CARD"  // Both players reveal their whole card
+	ShareTypeColor ShareType = "COLOR" // Both players reveal only their team color
+)
+
+// ShareStatus represents the state of a share request
+type ShareStatus string
+
+const (
+	ShareStatusPending  ShareStatus = "PENDING"  // Waiting for the target to respond
+	ShareStatusAccepted ShareStatus = "ACCEPTED" // Both players revealed to each other
+	ShareStatusDeclined ShareStatus = "DECLINED" // Target refused the share
+)
+
+// ShareRejection identifies the condition that stopped a share
+type ShareRejection string
+
+const (
+	ShareRejectedShy      ShareRejection = "SHY"      // Shy players cannot share at all
+	ShareRejectedCoy      ShareRejection = "COY"      // Coy players may only color share
+	ShareRejectedSavvy    ShareRejection = "SAVVY"    // Savvy players may only card share
+	ShareRejectedParanoid ShareRejection = "PARANOID" // Paranoid players already used their one card share
+	ShareRejectedFoolish  ShareRejection = "FOOLISH"  // Foolish players cannot refuse a share
+)
+
+// ShareError is returned when a player's conditions do not allow a share
+type ShareError struct {
+	Code     ShareRejection `json:"code"`     // Rejection reason
+	PlayerID string         `json:"playerId"` // Player whose condition caused the rejection
+}
+
+func (e *ShareError) Error() string {
+	switch e.Code {
+	case ShareRejectedShy:
+		return fmt.Sprintf("player %s is shy and cannot share", e.PlayerID)
+	case ShareRejectedCoy:
+		return fmt.Sprintf("player %s is coy and may only color share", e.PlayerID)
+	case ShareRejectedSavvy:
+		return fmt.Sprintf("player %s is savvy and may only card share", e.PlayerID)
+	case ShareRejectedParanoid:
+		return fmt.Sprintf("player %s is paranoid and already card shared this game", e.PlayerID)
+	case ShareRejectedFoolish:
+		return fmt.Sprintf("player %s is foolish and cannot refuse a share", e.PlayerID)
+	}
+	return fmt.Sprintf("share not allowed for player %s: %s", e.PlayerID, e.Code)
+}
+
+// ShareRecord represents a single card or color share between two players
+type ShareRecord struct {
+	ID          string      `json:"id"`                    // Share ID
+	Type        ShareType   `json:"type"`                  // CARD or COLOR
+	InitiatorID string      `json:"initiatorId"`           // Player who offered the share
+	TargetID    string      `json:"targetId"`              // Player who was asked to share
+	RoundNumber int         `json:"roundNumber"`           // Round the share was requested in
+	Status      ShareStatus `json:"status"`                // Current share status
+	ForcedBy    string      `json:"forcedBy,omitempty"`    // Player whose power forced the share (Agent, Enforcer)
+	RequestedAt time.Time   `json:"requestedAt"`           // Request timestamp
+	CompletedAt *time.Time  `json:"completedAt,omitempty"` // Accept/decline timestamp
+}
+
+// Involves reports whether the player took part in the share
+func (s *ShareRecord) Involves(playerID string) bool {
+	return s.InitiatorID == playerID || s.TargetID == playerID
+}
+
+// PartnerOf returns the other player in the share
+func (s *ShareRecord) PartnerOf(playerID string) string {
+	if s.InitiatorID == playerID {
+		return s.TargetID
+	}
+	return s.InitiatorID
+}
@@ -25,6 +25,7 @@ const (
 	VoteStatusActive    VoteSessionStatus = "ACTIVE"    // Vote in progress
 	VoteStatusCompleted VoteSessionStatus = "COMPLETED" // Vote finished normally
 	VoteStatusTimeout   VoteSessionStatus = "TIMEOUT"   // Vote expired
+	VoteStatusCancelled VoteSessionStatus = "CANCELLED" // Game ended before the vote finished
 )
 
 // VoteSession represents a leader removal or election vote
@@ -62,6 +63,7 @@ const (
 	VoteResultPassed  = "PASSED"
 	VoteResultFailed  = "FAILED"
 	VoteResultTimeout = "TIMEOUT"
+	VoteResultCancelled = "CANCELLED"
 )
 
 // LeadershipChangeReason represents why leadership changed
@@ -71,4 +73,5 @@ const (
 	ReasonVoluntaryTransfer LeadershipChangeReason = "VOLUNTARY_TRANSFER" // Leader voluntarily transferred
 	ReasonDisconnection     LeadershipChangeReason = "DISCONNECTION"      // Leader disconnected
 	ReasonVoteRemoval       LeadershipChangeReason = "VOTE_REMOVAL"       // Removed by vote
+	ReasonAssigned          LeadershipChangeReason = "ASSIGNED"           // Randomly assigned at the start of the game
 )
@@ -0,0 +1,9 @@
+package models
+
+// WinCondition is a Grey role's declarative goal (mirrors config.WinCondition)
+type WinCondition struct {
+	SameRoomAs        []string `json:"sameRoomAs,omitempty"`        // End in the same room as each of these roles
+	DifferentRoomFrom []string `json:"differentRoomFrom,omitempty"` // End in a different room from each of these roles
+	ExclusiveWith     []string `json:"exclusiveWith,omitempty"`     // Lose if any of these roles also meets its own room goal
+	Expression        string   `json:"expression,omitempty"`        // Expression over the end-of-game state that must be true
+}
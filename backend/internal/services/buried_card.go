@@ -0,0 +1,121 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
+)
+
+// buriedSlotID identifies the placeholder seat that receives the buried card while dealing
+const buriedSlotID = "__buried__"
+
+// settleBuriedCard re-deals the buried card when the rules forbid burying it
+// A Drunk is shuffled in after the sober card is set aside, so it is never buried itself.
+// A leader (President, Bomber) may only be buried when a backup for it (President's
+// Daughter, Martyr) or a Drunk is dealt, so someone carries out the leader's responsibilities.
+// The buried card is swapped with a random dealt card that may be buried, chosen with intn.
+func settleBuriedCard(slot *models.Player, players []*models.Player, intn func(n int) int) {
+	buried := slot.Role
+	if buried == nil || !mustNotBury(buried, players) {
+		return
+	}
+
+	var candidates []*models.Player
+	for _, player := range players {
+		if player.Role != nil && !player.Role.IsLeader && player.Role.SwapBuriedRound == 0 {
+			candidates = append(candidates, player)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	swap := candidates[intn(len(candidates))]
+	slot.Role, swap.Role = swap.Role, slot.Role
+	swap.Team = swap.Role.Team
+	log.Printf("[DEBUG] %s cannot be buried, buried %s instead", buried.ID, slot.Role.ID)
+}
+
+// mustNotBury reports whether the role is not allowed to be the buried card
+func mustNotBury(role *models.Role, players []*models.Player) bool {
+	if role.SwapBuriedRound > 0 {
+		return true
+	}
+	if !role.IsLeader {
+		return false
+	}
+	for _, player := range players {
+		if player.Role != nil && (player.Role.BackupFor == role.ID || player.Role.SwapBuriedRound > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// swapBuriedCards trades the buried card with any role that swaps at the given round (Drunk)
+// The buried card is dealt to the holder, and the holder's card becomes the new buried card.
+// Returns the players whose card changed.
+func swapBuriedCards(room *models.Room, roundNumber int) []*models.Player {
+	session := room.GameSession
+	var swapped []*models.Player
+	for _, player := range room.Players {
+		if session.BuriedRole == nil {
+			break
+		}
+		if player.Role == nil || player.Role.SwapBuriedRound != roundNumber {
+			continue
+		}
+
+		now := time.Now()
+		session.CardTransfers = append(session.CardTransfers,
+			&models.CardTransfer{
+				RoleID:        player.Role.ID,
+				FromPlayerID:  player.ID,
+				ToPlayerID:    models.BuriedCard,
+				RoundNumber:   roundNumber,
+				TransferredAt: now,
+			},
+			&models.CardTransfer{
+				RoleID:        session.BuriedRole.ID,
+				FromPlayerID:  models.BuriedCard,
+				ToPlayerID:    player.ID,
+				RoundNumber:   roundNumber,
+				TransferredAt: now,
+			},
+		)
+
+		player.Role, session.BuriedRole = session.BuriedRole, player.Role
+		// Zombies stay zombies whatever card they hold
+		if player.Team != models.TeamZombie {
+			player.Team = player.Role.Team
+		}
+		player.Cleanse()
+		swapped = append(swapped, player)
+
+		log.Printf("[INFO] Buried card swapped: room=%s player=%s round=%d", room.Code, player.ID, roundNumber)
+	}
+	return swapped
+}
+
+// sendRoleAssigned privately tells a player the card they now hold
+func (rm *RoundManager) sendRoleAssigned(roomCode string, player *models.Player) {
+	if rm.hub == nil {
+		return
+	}
+
+	msg, err := websocket.NewMessage(websocket.MessageRoleAssigned, &websocket.RoleAssignedPayload{
+		Role:        player.Role,
+		Team:        player.Team,
+		CurrentRoom: player.CurrentRoom,
+		Conditions:  append([]models.Condition{}, player.Conditions...),
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to create ROLE_ASSIGNED message: %v", err)
+		return
+	}
+
+	data, _ := msg.Marshal()
+	rm.hub.SendToClient(roomCode, player.ID, data)
+}
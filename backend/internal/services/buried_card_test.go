@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// buryTestConfig is a minimal role configuration with the given bury setting and extra roles
+const buryTestConfig = `{
+  "id": "bury-test",
+  "name": "Bury Test",
+  "version": "1.0.0",
+  "bury": %q,
+  "roles": [
+    {"id": "PRESIDENT", "name": "President", "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1},
+    {"id": "BLUE_TEAM", "name": "Blue Team", "team": "BLUE", "type": "operative", "count": 99, "minPlayers": 6, "priority": 99},
+    {"id": "BOMBER", "name": "Bomber", "team": "RED", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1},
+    {"id": "RED_TEAM", "name": "Red Team", "team": "RED", "type": "operative", "count": 99, "minPlayers": 6, "priority": 99},
+    {"id": "DRUNK", "name": "Drunk", "team": "GREY", "type": "grey", "count": 1, "minPlayers": 6, "priority": 3, "swapBuriedRound": 3}
+  ]
+}`
+
+// newBuryTestService creates a GameService whose loader holds the bury test configuration
+func newBuryTestService(t *testing.T, bury config.BuryMode) *GameService {
+	t.Helper()
+	dir := t.TempDir()
+	data := fmt.Sprintf(buryTestConfig, bury)
+	if err := os.WriteFile(filepath.Join(dir, "bury-test.json"), []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	loader := config.NewRoleConfigLoader(dir)
+	if err := loader.LoadAll(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	return NewGameService(nil, loader)
+}
+
+// loadFormatTestConfig loads a single configuration file written under the given name
+func loadFormatTestConfig(t *testing.T, name, data string) (*config.RoleConfigLoader, string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	loader := config.NewRoleConfigLoader(dir)
+	return loader, path, loader.LoadAll()
+}
+
+func newBuryTestPlayers(count int) []*models.Player {
+	players := make([]*models.Player, count)
+	for i := range players {
+		players[i] = &models.Player{ID: fmt.Sprintf("player-%d", i)}
+	}
+	return players
+}
+
+func TestAssignRolesWithConfig_Bury(t *testing.T) {
+	tests := []struct {
+		name          string
+		bury          config.BuryMode
+		playerCount   int
+		selectedRoles map[string]int
+		wantBuried    bool
+	}{
+		{name: "never buries", bury: config.BuryNever, playerCount: 7, selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1}},
+		{name: "odd buries with odd player count", bury: config.BuryOdd, playerCount: 7, selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1}, wantBuried: true},
+		{name: "odd skips even player count", bury: config.BuryOdd, playerCount: 8, selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1}},
+		{name: "always buries", bury: config.BuryAlways, playerCount: 8, selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1}, wantBuried: true},
+		{name: "drunk forces a buried card", bury: config.BuryNever, playerCount: 8, selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "DRUNK": 1}, wantBuried: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newBuryTestService(t, tt.bury)
+
+			// Dealing is random, so repeat to cover different buried cards
+			for i := 0; i < 20; i++ {
+				players := newBuryTestPlayers(tt.playerCount)
+				buried, err := service.AssignRolesWithConfig(players, "bury-test", tt.selectedRoles)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+
+				if (buried != nil) != tt.wantBuried {
+					t.Fatalf("Expected buried=%v, got %+v", tt.wantBuried, buried)
+				}
+				for _, player := range players {
+					if player.Role == nil {
+						t.Fatalf("Player %s has no role", player.ID)
+					}
+				}
+				if buried == nil {
+					continue
+				}
+
+				if buried.ID == "DRUNK" {
+					t.Error("Drunk must never be buried")
+				}
+				// Leaders are only buried when a Drunk will take the card back
+				if buried.IsLeader && tt.selectedRoles["DRUNK"] == 0 {
+					t.Errorf("Leader %s buried without a backup", buried.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSettleBuriedCard(t *testing.T) {
+	president := models.Role{ID: "PRESIDENT", Team: models.TeamBlue, IsLeader: true}
+	daughter := models.Role{ID: "PRESIDENTS_DAUGHTER", Team: models.TeamBlue, BackupFor: "PRESIDENT"}
+	drunk := models.Role{ID: "DRUNK", Team: models.TeamGrey, SwapBuriedRound: 3}
+	operative := models.Role{ID: "RED_TEAM", Team: models.TeamRed}
+
+	tests := []struct {
+		name       string
+		buried     models.Role
+		dealt      []models.Role
+		wantBuried string
+	}{
+		{name: "operative stays buried", buried: operative, dealt: []models.Role{president, operative}, wantBuried: "RED_TEAM"},
+		{name: "leader without backup is re-dealt", buried: president, dealt: []models.Role{operative}, wantBuried: "RED_TEAM"},
+		{name: "leader with backup stays buried", buried: president, dealt: []models.Role{daughter}, wantBuried: "PRESIDENT"},
+		{name: "leader with drunk stays buried", buried: president, dealt: []models.Role{drunk, operative}, wantBuried: "PRESIDENT"},
+		{name: "drunk is re-dealt", buried: drunk, dealt: []models.Role{president, operative}, wantBuried: "RED_TEAM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buried := tt.buried
+			slot := &models.Player{ID: buriedSlotID, Role: &buried}
+			var players []*models.Player
+			for i := range tt.dealt {
+				role := tt.dealt[i]
+				players = append(players, &models.Player{ID: role.ID, Role: &role, Team: role.Team})
+			}
+
+			settleBuriedCard(slot, players, rand.Intn)
+
+			if slot.Role.ID != tt.wantBuried {
+				t.Errorf("Expected buried %s, got %s", tt.wantBuried, slot.Role.ID)
+			}
+			for _, player := range players {
+				if player.Team != player.Role.Team {
+					t.Errorf("Player %s holds %s but is on team %s", player.ID, player.Role.ID, player.Team)
+				}
+			}
+		})
+	}
+}
+
+func TestSwapBuriedCards(t *testing.T) {
+	newRoom := func() *models.Room {
+		return &models.Room{
+			Code: "BURY01",
+			Players: []*models.Player{
+				{ID: "drunk", Team: models.TeamGrey, Role: &models.Role{ID: "DRUNK", Team: models.TeamGrey, SwapBuriedRound: 3}, Conditions: []models.Condition{models.ConditionShy}},
+				{ID: "president", Team: models.TeamBlue, Role: &models.Role{ID: "PRESIDENT", Team: models.TeamBlue}},
+			},
+			GameSession: &models.GameSession{
+				BuriedRole: &models.Role{ID: "BOMBER", Team: models.TeamRed, IsLeader: true},
+			},
+		}
+	}
+
+	t.Run("no swap before the configured round", func(t *testing.T) {
+		room := newRoom()
+		if swapped := swapBuriedCards(room, 2); len(swapped) != 0 {
+			t.Fatalf("Expected no swap in round 2, got %d", len(swapped))
+		}
+		if room.GameSession.BuriedRole.ID != "BOMBER" {
+			t.Errorf("Expected BOMBER to stay buried, got %s", room.GameSession.BuriedRole.ID)
+		}
+	})
+
+	t.Run("drunk takes the sober card", func(t *testing.T) {
+		room := newRoom()
+		swapped := swapBuriedCards(room, 3)
+		if len(swapped) != 1 || swapped[0].ID != "drunk" {
+			t.Fatalf("Expected the drunk to swap, got %v", swapped)
+		}
+
+		drunk := room.Players[0]
+		if drunk.Role.ID != "BOMBER" || drunk.Team != models.TeamRed {
+			t.Errorf("Expected drunk to become the Red Bomber, got %s (%s)", drunk.Role.ID, drunk.Team)
+		}
+		if len(drunk.Conditions) != 0 {
+			t.Errorf("Expected the sober card to be cleansed, got %v", drunk.Conditions)
+		}
+		if room.GameSession.BuriedRole.ID != "DRUNK" {
+			t.Errorf("Expected the Drunk card to be buried, got %s", room.GameSession.BuriedRole.ID)
+		}
+		if holder := room.GameSession.OriginalHolder("BOMBER", "drunk"); holder != models.BuriedCard {
+			t.Errorf("Expected BOMBER to come from the buried pile, got %s", holder)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// newCustomRoleSet builds an inline role set without metadata, as a host would send it
+func newCustomRoleSet(t *testing.T) *config.RoleConfig {
+	t.Helper()
+	var roleSet config.RoleConfig
+	data := fmt.Sprintf(buryTestConfig, config.BuryNever)
+	if err := json.Unmarshal([]byte(data), &roleSet); err != nil {
+		t.Fatalf("Failed to parse role set: %v", err)
+	}
+	roleSet.ID, roleSet.Name, roleSet.Version = "", "", ""
+	return &roleSet
+}
+
+func TestRoomService_CreateCustomRoom(t *testing.T) {
+	t.Run("stores a valid role set on the room", func(t *testing.T) {
+		roomService := NewRoomService(store.NewRoomStore())
+
+		room, err := roomService.CreateCustomRoom(10, true, newCustomRoleSet(t), map[string]int{"PRESIDENT": 1, "BOMBER": 1, "DRUNK": 1})
+		if err != nil {
+			t.Fatalf("CreateCustomRoom failed: %v", err)
+		}
+		if room.CustomRoleConfig == nil || room.RoleConfigID != "custom" {
+			t.Errorf("Expected the inline role set on the room, got roleConfigId=%s", room.RoleConfigID)
+		}
+	})
+
+	t.Run("rejects a role set that breaks the config rules", func(t *testing.T) {
+		roomService := NewRoomService(store.NewRoomStore())
+		roleSet := newCustomRoleSet(t)
+		roleSet.Roles[0].Team = "PURPLE"
+
+		_, err := roomService.CreateCustomRoom(10, true, roleSet, nil)
+		if !errors.Is(err, config.ErrInvalidConfig) {
+			t.Errorf("Expected ErrInvalidConfig, got %v", err)
+		}
+	})
+
+	t.Run("rejects a selection of undefined roles", func(t *testing.T) {
+		roomService := NewRoomService(store.NewRoomStore())
+
+		_, err := roomService.CreateCustomRoom(10, true, newCustomRoleSet(t), map[string]int{"SPY": 1})
+		if !errors.Is(err, models.ErrInvalidRoleSelection) {
+			t.Errorf("Expected ErrInvalidRoleSelection, got %v", err)
+		}
+	})
+}
+
+func TestRoomService_UpdateRoleSet(t *testing.T) {
+	gameService := newBuryTestService(t, config.BuryNever)
+	roomStore := store.NewRoomStore()
+	roomService := NewRoomService(roomStore)
+	roomService.SetRoleLoader(gameService.roleLoader)
+
+	room, err := roomService.CreateRoom(10, true, "bury-test", nil)
+	if err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+	room.Players = []*models.Player{
+		{ID: "owner", IsOwner: true},
+		{ID: "guest"},
+	}
+
+	if _, err := roomService.UpdateRoleSet(room.Code, "guest", "", newCustomRoleSet(t), nil); !errors.Is(err, models.ErrNotRoomOwner) {
+		t.Errorf("Expected ErrNotRoomOwner, got %v", err)
+	}
+
+	updated, err := roomService.UpdateRoleSet(room.Code, "owner", "", newCustomRoleSet(t), map[string]int{"PRESIDENT": 1, "BOMBER": 1})
+	if err != nil {
+		t.Fatalf("UpdateRoleSet failed: %v", err)
+	}
+	roleConfig, err := gameService.roomRoleConfig(updated)
+	if err != nil {
+		t.Fatalf("Expected the game to use the inline role set, got %v", err)
+	}
+	if got, want := roleIDs(roleConfig), roleIDs(newCustomRoleSet(t)); roleConfig.ID != "custom" || got != want {
+		t.Errorf("Expected the inline role set %q, got %s with %q", want, roleConfig.ID, got)
+	}
+
+	// Switching back to a server-wide configuration drops the inline set
+	updated, err = roomService.UpdateRoleSet(room.Code, "owner", "bury-test", nil, nil)
+	if err != nil {
+		t.Fatalf("UpdateRoleSet failed: %v", err)
+	}
+	if updated.CustomRoleConfig != nil || updated.RoleConfigID != "bury-test" || updated.RoleConfigRevision != 1 {
+		t.Errorf("Expected bury-test revision 1 without an inline set, got %s revision %d", updated.RoleConfigID, updated.RoleConfigRevision)
+	}
+
+	if _, err := roomService.UpdateRoleSet(room.Code, "owner", "missing", nil, nil); !errors.Is(err, config.ErrConfigNotFound) {
+		t.Errorf("Expected ErrConfigNotFound, got %v", err)
+	}
+
+	room.Status = models.RoomStatusInProgress
+	if _, err := roomService.UpdateRoleSet(room.Code, "owner", "", newCustomRoleSet(t), nil); !errors.Is(err, models.ErrGameAlreadyStarted) {
+		t.Errorf("Expected ErrGameAlreadyStarted, got %v", err)
+	}
+}
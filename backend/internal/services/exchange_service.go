@@ -235,7 +235,7 @@ func (es *ExchangeService) ExecuteExchange(roomCode string) error {
 		// Red hostages -> Blue room
 		for _, hid := range roundState.RedHostages {
 			if player.ID == hid {
-				player.CurrentRoom = models.BlueRoom
+				room.GameSession.MovePlayer(player, models.BlueRoom, models.MoveReasonHostage)
 				log.Printf("[DEBUG] Moved player %s from RED to BLUE", player.Nickname)
 				break
 			}
@@ -244,7 +244,7 @@ func (es *ExchangeService) ExecuteExchange(roomCode string) error {
 		// Blue hostages -> Red room
 		for _, hid := range roundState.BlueHostages {
 			if player.ID == hid {
-				player.CurrentRoom = models.RedRoom
+				room.GameSession.MovePlayer(player, models.RedRoom, models.MoveReasonHostage)
 				log.Printf("[DEBUG] Moved player %s from BLUE to RED", player.Nickname)
 				break
 			}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
+)
+
+// defaultFinalActionDuration is how long players have to submit end-of-game choices
+const defaultFinalActionDuration = 60 * time.Second
+
+// SetFinalActionDuration sets the deadline for the post-round-3 final actions phase
+func (rm *RoundManager) SetFinalActionDuration(d time.Duration) {
+	rm.finalActionDuration = d
+}
+
+// finalActors returns the players whose role makes an end-of-game choice
+func finalActors(room *models.Room) []*models.Player {
+	var actors []*models.Player
+	for _, player := range room.Players {
+		if player.Role != nil && player.Role.FinalAction != "" {
+			actors = append(actors, player)
+		}
+	}
+	return actors
+}
+
+// finishGame ends the game after round 3
+// If any role makes an end-of-game choice (Sniper, Gambler) the FINAL_ACTIONS phase
+// runs first; otherwise the game goes straight to REVEALING.
+func (rm *RoundManager) finishGame(roomCode string) error {
+	room, err := rm.store.Get(roomCode)
+	if err != nil {
+		return err
+	}
+
+	if room.GameSession == nil {
+		return errors.New("no active game session")
+	}
+
+	actors := finalActors(room)
+	if len(actors) == 0 {
+		return rm.transitionToRevealing(roomCode)
+	}
+
+	return rm.startFinalActions(room, actors)
+}
+
+// startFinalActions opens the final actions phase and schedules its deadline
+func (rm *RoundManager) startFinalActions(room *models.Room, actors []*models.Player) error {
+	rm.finalMu.Lock()
+	defer rm.finalMu.Unlock()
+
+	if room.Status != models.RoomStatusInProgress {
+		return errors.New("game is not in progress")
+	}
+
+	duration := rm.finalActionDuration
+	if duration <= 0 {
+		duration = defaultFinalActionDuration
+	}
+
+	phase := &models.FinalActionsPhase{
+		Deadline: time.Now().Add(duration),
+		Pending:  make(map[string]bool),
+		Actions:  make(map[string]*models.FinalAction),
+	}
+	for _, actor := range actors {
+		phase.Pending[actor.ID] = true
+	}
+
+	room.Status = models.RoomStatusFinalActions
+	room.GameSession.FinalActions = phase
+
+	if err := rm.store.Update(room); err != nil {
+		return err
+	}
+
+	roomCode, sessionID := room.Code, room.GameSession.ID
+	rm.finalTimers[sessionID] = time.AfterFunc(duration, func() {
+		rm.expireFinalActions(roomCode, sessionID)
+	})
+
+	log.Printf("[INFO] Final actions started: room=%s actors=%d deadline=%s",
+		roomCode, len(actors), phase.Deadline.Format(time.RFC3339))
+
+	if rm.hub == nil {
+		return nil
+	}
+
+	startedMsg, _ := websocket.NewMessage(websocket.MessageFinalActionsStarted, &websocket.FinalActionsStartedPayload{
+		Deadline:        phase.Deadline,
+		DurationSeconds: int(duration.Seconds()),
+	})
+	startedData, _ := startedMsg.Marshal()
+	rm.hub.BroadcastToRoom(roomCode, startedData)
+
+	// Only the acting players learn that a choice is expected from them
+	for _, actor := range actors {
+		msg, _ := websocket.NewMessage(websocket.MessageFinalActionRequired, &websocket.FinalActionRequiredPayload{
+			ActionType: actor.Role.FinalAction,
+			Deadline:   phase.Deadline,
+		})
+		data, _ := msg.Marshal()
+		rm.hub.SendToClient(roomCode, actor.ID, data)
+	}
+
+	return nil
+}
+
+// SubmitFinalAction records a player's end-of-game choice
+// SHOOT requires a target player; PREDICT requires a RED, BLUE or NONE prediction.
+// Once every acting player has submitted, the game moves to REVEALING.
+func (rm *RoundManager) SubmitFinalAction(roomCode, playerID, targetID string, prediction models.TeamColor) (*models.FinalAction, error) {
+	rm.finalMu.Lock()
+	defer rm.finalMu.Unlock()
+
+	room, err := rm.store.Get(roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Status != models.RoomStatusFinalActions || room.GameSession == nil || room.GameSession.FinalActions == nil {
+		return nil, errors.New("final actions phase is not active")
+	}
+
+	phase := room.GameSession.FinalActions
+	if _, submitted := phase.Actions[playerID]; submitted {
+		return nil, errors.New("final action already submitted")
+	}
+	if !phase.Pending[playerID] {
+		return nil, errors.New("player has no final action")
+	}
+
+	player := findPlayer(room, playerID)
+	if player == nil || player.Role == nil {
+		return nil, models.ErrPlayerNotFound
+	}
+
+	action := &models.FinalAction{
+		PlayerID:    playerID,
+		RoleID:      player.Role.ID,
+		Type:        player.Role.FinalAction,
+		SubmittedAt: time.Now(),
+	}
+
+	switch action.Type {
+	case models.FinalActionShoot:
+		if targetID == "" {
+			return nil, errors.New("target player required")
+		}
+		if targetID == playerID {
+			return nil, errors.New("cannot target yourself")
+		}
+		if findPlayer(room, targetID) == nil {
+			return nil, errors.New("invalid target player")
+		}
+		action.TargetID = targetID
+	case models.FinalActionPredict:
+		if prediction != models.TeamRed && prediction != models.TeamBlue && prediction != models.TeamNone {
+			return nil, errors.New("prediction must be RED, BLUE or NONE")
+		}
+		action.Prediction = prediction
+	default:
+		return nil, errors.New("unknown final action")
+	}
+
+	phase.Actions[playerID] = action
+	delete(phase.Pending, playerID)
+
+	if err := rm.store.Update(room); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Final action submitted: room=%s player=%s type=%s remaining=%d",
+		roomCode, playerID, action.Type, len(phase.Pending))
+
+	if rm.hub != nil {
+		msg, _ := websocket.NewMessage(websocket.MessageFinalActionRecorded, &websocket.FinalActionRecordedPayload{
+			ActionType: action.Type,
+			TargetID:   action.TargetID,
+			Prediction: action.Prediction,
+		})
+		data, _ := msg.Marshal()
+		rm.hub.SendToClient(roomCode, playerID, data)
+	}
+
+	if phase.Complete() {
+		if timer, exists := rm.finalTimers[room.GameSession.ID]; exists {
+			timer.Stop()
+			delete(rm.finalTimers, room.GameSession.ID)
+		}
+		if err := rm.transitionToRevealing(roomCode); err != nil {
+			return action, err
+		}
+	}
+
+	return action, nil
+}
+
+// expireFinalActions closes the phase at the deadline; missing choices are skipped
+func (rm *RoundManager) expireFinalActions(roomCode, sessionID string) {
+	rm.finalMu.Lock()
+	defer rm.finalMu.Unlock()
+
+	delete(rm.finalTimers, sessionID)
+
+	room, err := rm.store.Get(roomCode)
+	if err != nil {
+		return
+	}
+
+	// The phase may already be over, or the game reset and restarted
+	if room.Status != models.RoomStatusFinalActions || room.GameSession == nil || room.GameSession.ID != sessionID {
+		return
+	}
+
+	log.Printf("[INFO] Final actions deadline reached: room=%s skipped=%d", roomCode, len(room.GameSession.FinalActions.Pending))
+
+	if err := rm.transitionToRevealing(roomCode); err != nil {
+		log.Printf("[ERROR] Failed to reveal after final actions: %v", err)
+	}
+}
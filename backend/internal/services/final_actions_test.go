@@ -0,0 +1,180 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// newFinalActionsTestRoom creates a room that just finished round 3
+// The sniper and gambler make end-of-game choices unless withActors is false
+func newFinalActionsTestRoom(t *testing.T, roomStore *store.RoomStore, withActors bool) *models.Room {
+	t.Helper()
+	room := &models.Room{
+		Code:   "FINAL1",
+		Status: models.RoomStatusInProgress,
+		Players: []*models.Player{
+			{ID: "president", Nickname: "대통령", Team: models.TeamBlue, Role: &models.Role{ID: "PRESIDENT", Team: models.TeamBlue}, CurrentRoom: models.BlueRoom},
+			{ID: "bomber", Nickname: "폭파범", Team: models.TeamRed, Role: &models.Role{ID: "BOMBER", Team: models.TeamRed}, CurrentRoom: models.RedRoom},
+			{ID: "sniper", Nickname: "스나이퍼", Team: models.TeamGrey, Role: &models.Role{ID: "SNIPER", Team: models.TeamGrey}, CurrentRoom: models.RedRoom},
+			{ID: "target", Nickname: "타깃", Team: models.TeamGrey, Role: &models.Role{ID: "TARGET", Team: models.TeamGrey}, CurrentRoom: models.BlueRoom},
+			{ID: "decoy", Nickname: "미끼", Team: models.TeamGrey, Role: &models.Role{ID: "DECOY", Team: models.TeamGrey}, CurrentRoom: models.BlueRoom},
+			{ID: "gambler", Nickname: "갬블러", Team: models.TeamGrey, Role: &models.Role{ID: "GAMBLER", Team: models.TeamGrey}, CurrentRoom: models.RedRoom},
+		},
+		GameSession: &models.GameSession{
+			ID:           "session-final",
+			CurrentRound: 3,
+			RoundState:   &models.RoundState{RoundNumber: 3, Status: models.RoundStatusComplete},
+		},
+	}
+	if withActors {
+		findPlayer(room, "sniper").Role.FinalAction = models.FinalActionShoot
+		findPlayer(room, "gambler").Role.FinalAction = models.FinalActionPredict
+	}
+	if err := roomStore.Create(room); err != nil {
+		t.Fatalf("Failed to create room: %v", err)
+	}
+	return room
+}
+
+func TestRoundManager_FinishGame(t *testing.T) {
+	t.Run("no final actions goes straight to reveal", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, false)
+		rm := NewRoundManager(nil, roomStore)
+
+		if err := rm.finishGame(room.Code); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if room.Status != models.RoomStatusRevealing {
+			t.Errorf("Expected REVEALING, got %s", room.Status)
+		}
+	})
+
+	t.Run("final actions phase runs before reveal", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, true)
+		rm := NewRoundManager(nil, roomStore)
+
+		if err := rm.finishGame(room.Code); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if room.Status != models.RoomStatusFinalActions {
+			t.Fatalf("Expected FINAL_ACTIONS, got %s", room.Status)
+		}
+
+		if _, err := rm.SubmitFinalAction(room.Code, "sniper", "target", ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if room.Status != models.RoomStatusFinalActions {
+			t.Errorf("Expected to wait for the gambler, got %s", room.Status)
+		}
+
+		if _, err := rm.SubmitFinalAction(room.Code, "gambler", "", models.TeamBlue); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if room.Status != models.RoomStatusRevealing {
+			t.Fatalf("Expected REVEALING after all choices, got %s", room.Status)
+		}
+
+		outcome := room.GameSession.Outcome
+		if len(outcome.FinalActions) != 2 {
+			t.Errorf("Expected 2 final actions in the reveal, got %d", len(outcome.FinalActions))
+		}
+		for _, roleID := range []string{"SNIPER", "GAMBLER"} {
+			if result := findPlayerOutcome(t, outcome, roleID); !result.Won {
+				t.Errorf("Expected %s to win, got %s", roleID, result.Reason)
+			}
+		}
+	})
+
+	t.Run("deadline skips missing choices", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, true)
+		rm := NewRoundManager(nil, roomStore)
+		rm.SetFinalActionDuration(20 * time.Millisecond)
+
+		if err := rm.finishGame(room.Code); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			updated, _ := roomStore.Get(room.Code)
+			if updated.Status == models.RoomStatusRevealing {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Error("Expected REVEALING after the deadline")
+	})
+}
+
+func TestRoundManager_SubmitFinalAction(t *testing.T) {
+	tests := []struct {
+		name       string
+		playerID   string
+		targetID   string
+		prediction models.TeamColor
+	}{
+		{name: "reject player without final action", playerID: "target", targetID: "sniper"},
+		{name: "reject sniper without target", playerID: "sniper"},
+		{name: "reject sniper shooting self", playerID: "sniper", targetID: "sniper"},
+		{name: "reject unknown target", playerID: "sniper", targetID: "nobody"},
+		{name: "reject invalid prediction", playerID: "gambler", prediction: models.TeamGrey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newFinalActionsTestRoom(t, roomStore, true)
+			rm := NewRoundManager(nil, roomStore)
+			if err := rm.finishGame(room.Code); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if _, err := rm.SubmitFinalAction(room.Code, tt.playerID, tt.targetID, tt.prediction); err == nil {
+				t.Error("Expected error")
+			}
+		})
+	}
+
+	t.Run("reject outside the phase", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, true)
+		rm := NewRoundManager(nil, roomStore)
+
+		if _, err := rm.SubmitFinalAction(room.Code, "sniper", "target", ""); err == nil {
+			t.Error("Expected error before the phase starts")
+		}
+	})
+
+	t.Run("reject second submission", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, true)
+		rm := NewRoundManager(nil, roomStore)
+		rm.finishGame(room.Code)
+
+		rm.SubmitFinalAction(room.Code, "sniper", "target", "")
+		if _, err := rm.SubmitFinalAction(room.Code, "sniper", "decoy", ""); err == nil {
+			t.Error("Expected error for second submission")
+		}
+	})
+
+	t.Run("accept a prediction that neither team wins", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newFinalActionsTestRoom(t, roomStore, true)
+		rm := NewRoundManager(nil, roomStore)
+		rm.finishGame(room.Code)
+
+		action, err := rm.SubmitFinalAction(room.Code, "gambler", "", models.TeamNone)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if action.Prediction != models.TeamNone {
+			t.Errorf("Expected prediction NONE, got %s", action.Prediction)
+		}
+	})
+}
@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"sort"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/store"
 )
@@ -21,6 +21,7 @@ type GameService struct {
 	roleLoader   *config.RoleConfigLoader
 	roundManager *RoundManager
 	leaderService *LeaderService
+	rng           *rand.Rand // Source for dealing roles; nil uses the global source, which seeds itself
 }
 
 // Hub interface for WebSocket broadcasts
@@ -57,6 +58,31 @@ func (s *GameService) SetHub(hub Hub) {
 	s.hub = hub
 }
 
+// SetRand sets the random source roles are dealt with
+// A seeded source makes dealing repeatable (e.g. for simulations); it is not safe for
+// concurrent use, so a service with one must not deal for several rooms at once.
+func (s *GameService) SetRand(rng *rand.Rand) {
+	s.rng = rng
+}
+
+// shuffle shuffles with the service's random source
+// The global source is never reseeded, so a seeded source set with SetRand alone decides the deal.
+func (s *GameService) shuffle(n int, swap func(i, j int)) {
+	if s.rng != nil {
+		s.rng.Shuffle(n, swap)
+		return
+	}
+	rand.Shuffle(n, swap)
+}
+
+// intn returns a random number in [0, n) from the service's random source
+func (s *GameService) intn(n int) int {
+	if s.rng != nil {
+		return s.rng.Intn(n)
+	}
+	return rand.Intn(n)
+}
+
 // T069: Implement team assignment algorithm (AssignTeams - FR-008)
 // Assigns players to RED and BLUE teams with equal split
 // If odd number of players, RED team gets the extra player
@@ -161,231 +187,114 @@ func AssignRoles(players []*models.Player) {
 }
 
 // AssignRolesWithConfig assigns roles using a role configuration
-func (s *GameService) AssignRolesWithConfig(players []*models.Player, configID string, selectedRoles map[string]int) error {
+// If the configuration buries a card, one extra card is dealt face down and returned.
+func (s *GameService) AssignRolesWithConfig(players []*models.Player, configID string, selectedRoles map[string]int) (*models.Role, error) {
 	// Get configuration
 	roleConfig, err := s.roleLoader.Get(configID)
 	if err != nil {
-		return fmt.Errorf("failed to get role config: %w", err)
+		return nil, fmt.Errorf("failed to get role config: %w", err)
 	}
+	return s.assignRolesFromConfig(players, roleConfig, selectedRoles)
+}
 
-	totalPlayers := len(players)
-
-	// Filter roleConfig.Roles to only include selected roles
-	var selectedRoleDefs []config.RoleDefinition
-	totalSelectedCount := 0
-
-	if selectedRoles != nil && len(selectedRoles) > 0 {
-		// Use only selected roles with their specified counts
-		log.Printf("[DEBUG] Using selected roles: %+v", selectedRoles)
-		for _, roleDef := range roleConfig.Roles {
-			if count, ok := selectedRoles[roleDef.ID]; ok && count > 0 {
-				// Override the role's count with the selected count
-				roleDefCopy := roleDef
-				roleDefCopy.Count = config.RoleCount{Fixed: &count}
-				selectedRoleDefs = append(selectedRoleDefs, roleDefCopy)
-				totalSelectedCount += count
-				log.Printf("[DEBUG] Selected role: %s (count=%d, team=%s)", roleDef.ID, count, roleDef.Team)
-			}
-		}
-		log.Printf("[DEBUG] Total selected roles: %d, Total players: %d", totalSelectedCount, totalPlayers)
-	} else {
-		// No selection provided, use all roles from config
-		log.Printf("[DEBUG] No selected roles provided, using all roles from config")
-		selectedRoleDefs = roleConfig.Roles
-		for _, roleDef := range selectedRoleDefs {
-			if roleDef.MinPlayers <= totalPlayers {
-				totalSelectedCount += roleDef.Count.GetCount(totalPlayers)
-			}
-		}
+// roomRoleConfig returns the role configuration a room plays with: its inline role set,
+// or the revision of the server-wide configuration that was current when it was created
+func (s *GameService) roomRoleConfig(room *models.Room) (*config.RoleConfig, error) {
+	if room.CustomRoleConfig != nil {
+		return customRoleSet(room)
 	}
 
-	// Validate that we have enough roles for all players
-	if totalSelectedCount > totalPlayers {
-		return fmt.Errorf("too many roles selected (%d) for player count (%d)", totalSelectedCount, totalPlayers)
-	}
-
-	// Calculate how many roles we need for each team
-	redRoleCount := 0
-	blueRoleCount := 0
-	greyRoleCount := 0
-	for _, roleDef := range selectedRoleDefs {
-		// When roles are explicitly selected, ignore minPlayers restriction
-		// since the user is consciously choosing them
-		count := roleDef.Count.GetCount(totalPlayers)
-		switch roleDef.Team {
-		case config.TeamRed:
-			redRoleCount += count
-		case config.TeamBlue:
-			blueRoleCount += count
-		case config.TeamGrey:
-			greyRoleCount += count
-		}
+	// Use room's roleConfigId, default to "standard" if not set
+	roleConfigID := room.RoleConfigID
+	if roleConfigID == "" {
+		roleConfigID = config.DefaultConfigID
 	}
-
-	log.Printf("[DEBUG] Role counts by team: RED=%d, BLUE=%d, GREY=%d", redRoleCount, blueRoleCount, greyRoleCount)
-
-	// Separate players by team, reserving some for Grey team
-	var redTeam []*models.Player
-	var blueTeam []*models.Player
-	var greyPool []*models.Player
-
-	// Shuffle players for team assignment
-	rand.Seed(time.Now().UnixNano())
-	shuffled := make([]*models.Player, len(players))
-	copy(shuffled, players)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
-
-	// Assign players to teams based on role counts
-	for i, player := range shuffled {
-		if i < greyRoleCount {
-			// Mark for Grey team
-			player.Team = models.TeamGrey
-			greyPool = append(greyPool, player)
-		} else if i < greyRoleCount+redRoleCount {
-			// Mark for Red team
-			player.Team = models.TeamRed
-			redTeam = append(redTeam, player)
-		} else {
-			// Mark for Blue team
-			player.Team = models.TeamBlue
-			blueTeam = append(blueTeam, player)
-		}
+	if s.roleLoader == nil {
+		return nil, errors.New("no role loader available")
 	}
-
-	log.Printf("[DEBUG] Team distribution: RED=%d, BLUE=%d, GREY=%d", len(redTeam), len(blueTeam), len(greyPool))
-
-	// Create a temporary config with only selected roles
-	tempConfig := &config.RoleConfig{
-		ID:            roleConfig.ID,
-		Name:          roleConfig.Name,
-		NameKo:        roleConfig.NameKo,
-		Description:   roleConfig.Description,
-		DescriptionKo: roleConfig.DescriptionKo,
-		Roles:         selectedRoleDefs,
+	roleConfig, err := s.roleLoader.GetRevision(roleConfigID, room.RoleConfigRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role config: %w", err)
 	}
+	return roleConfig, nil
+}
 
-	// Assign RED team roles
-	if err := s.assignTeamRoles(redTeam, config.TeamRed, totalPlayers, tempConfig); err != nil {
-		return fmt.Errorf("failed to assign RED team roles: %w", err)
+// resolveRoomRoles returns the role configuration and selection a room's game is dealt from
+// With autoFix a selection that cannot be dealt is adjusted, and the changes are returned.
+func (s *GameService) resolveRoomRoles(room *models.Room, autoFix bool) (*config.RoleConfig, map[string]int, []*models.RoleCountChange, error) {
+	roleConfig, err := s.roomRoleConfig(room)
+	if err != nil {
+		return nil, nil, nil, &RoleAssignmentError{Code: RoleErrConfigNotFound, Errors: []string{err.Error()}}
 	}
 
-	// Assign BLUE team roles
-	if err := s.assignTeamRoles(blueTeam, config.TeamBlue, totalPlayers, tempConfig); err != nil {
-		return fmt.Errorf("failed to assign BLUE team roles: %w", err)
+	playerCount := len(room.Players)
+	plan := PlanRoleDistribution(roleConfig, playerCount, room.SelectedRoles)
+	if plan.Err() == nil {
+		return roleConfig, room.SelectedRoles, nil, nil
 	}
 
-	// Assign GREY team roles
-	if err := s.assignTeamRoles(greyPool, config.TeamGrey, totalPlayers, tempConfig); err != nil {
-		return fmt.Errorf("failed to assign GREY team roles: %w", err)
+	fixed, changes := AutoFixRoleSelection(roleConfig, playerCount, room.SelectedRoles)
+	fixable := PlanRoleDistribution(roleConfig, playerCount, fixed).Err() == nil
+	if autoFix && fixable {
+		log.Printf("[INFO] Auto-fixed role selection in room %s: %d change(s)", room.Code, len(changes))
+		return roleConfig, fixed, changes, nil
 	}
 
-	return nil
+	assignErr := &RoleAssignmentError{Code: RoleErrInvalidDistribution, Errors: plan.Errors}
+	if fixable {
+		assignErr.Fix = changes
+	}
+	return nil, nil, nil, assignErr
 }
 
-// assignTeamRoles assigns roles to a team based on configuration
-func (s *GameService) assignTeamRoles(team []*models.Player, teamColor config.TeamColor, totalPlayers int, roleConfig *config.RoleConfig) error {
-	log.Printf("[DEBUG] assignTeamRoles: team=%s teamSize=%d totalPlayers=%d", teamColor, len(team), totalPlayers)
-
-	// Filter applicable roles for this team
-	// Note: We don't check MinPlayers here because if roles are in the config
-	// passed to this function, they were already explicitly selected by the user
-	var applicableRoles []config.RoleDefinition
-	for _, roleDef := range roleConfig.Roles {
-		if roleDef.Team == teamColor {
-			applicableRoles = append(applicableRoles, roleDef)
-			log.Printf("[DEBUG] Applicable role for %s: %s (priority=%d)", teamColor, roleDef.ID, roleDef.Priority)
-		}
+// assignRolesFromConfig assigns roles from an already resolved role configuration
+// The cards follow PlanRoleDistribution exactly; only the seating is random.
+// If the configuration buries a card, one extra card is dealt face down and returned.
+func (s *GameService) assignRolesFromConfig(players []*models.Player, roleConfig *config.RoleConfig, selectedRoles map[string]int) (*models.Role, error) {
+	plan := PlanRoleDistribution(roleConfig, len(players), selectedRoles)
+	if err := plan.Err(); err != nil {
+		return nil, err
 	}
 
-	// Sort by priority (lower priority = assigned first)
-	sort.Slice(applicableRoles, func(i, j int) bool {
-		return applicableRoles[i].Priority < applicableRoles[j].Priority
-	})
-
-	// Shuffle team for randomization
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(team), func(i, j int) {
-		team[i], team[j] = team[j], team[i]
-	})
-
-	// Assign roles based on priority
-	playerIndex := 0
-	for _, roleDef := range applicableRoles {
-		count := roleDef.Count.GetCount(totalPlayers)
-		for i := 0; i < count && playerIndex < len(team); i++ {
-			// Create role from config definition
-			role := createRoleFromConfig(roleDef)
-			team[playerIndex].Role = &role
-			log.Printf("[DEBUG] Assigned role %s to player %s (team=%s)", role.ID, team[playerIndex].ID, teamColor)
-			playerIndex++
-		}
+	// Bury one extra card for a hidden seat; it is dealt like any other card
+	var buriedSlot *models.Player
+	deck := players
+	if plan.Buried {
+		buriedSlot = &models.Player{ID: buriedSlotID}
+		deck = append(append([]*models.Player{}, players...), buriedSlot)
+		log.Printf("[DEBUG] Burying one card (bury=%s, players=%d)", roleConfig.Bury, len(players))
 	}
 
-	// Fill remaining players with default operative roles
-	if playerIndex < len(team) {
-		log.Printf("[DEBUG] Filling %d remaining players with default operative role for team %s", len(team)-playerIndex, teamColor)
-
-		// Find the default operative role for this team
-		var defaultRole *config.RoleDefinition
-		for _, roleDef := range roleConfig.Roles {
-			if roleDef.Team == teamColor && roleDef.Type == config.RoleTypeOperative && roleDef.Priority == 99 {
-				defaultRole = &roleDef
-				break
-			}
-		}
+	// Shuffle seats, then deal each team's cards in turn
+	shuffled := make([]*models.Player, len(deck))
+	copy(shuffled, deck)
+	s.shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
 
-		// If no default role found in config, create a basic operative role
-		if defaultRole == nil {
-			log.Printf("[DEBUG] No default operative role found in config, creating basic role for team %s", teamColor)
-			var teamName, teamNameKo, description, descriptionKo, icon string
-			switch teamColor {
-			case config.TeamRed:
-				teamName = "Red Team"
-				teamNameKo = "레드 팀원"
-				description = "Standard Red Team member"
-				descriptionKo = "레드 팀의 일반 요원"
-				icon = "⭐"
-			case config.TeamBlue:
-				teamName = "Blue Team"
-				teamNameKo = "블루 팀원"
-				description = "Standard Blue Team member"
-				descriptionKo = "블루 팀의 일반 요원"
-				icon = "⭐"
-			case config.TeamGrey:
-				teamName = "Grey Team"
-				teamNameKo = "그레이 팀원"
-				description = "Independent player"
-				descriptionKo = "독립 플레이어"
-				icon = "⚪"
+	seat := 0
+	for _, teamColor := range dealingOrder {
+		for _, team := range plan.Teams {
+			if team.Team != teamColor {
+				continue
 			}
-
-			basicRole := config.RoleDefinition{
-				ID:            string(teamColor) + "_TEAM",
-				Name:          teamName,
-				NameKo:        teamNameKo,
-				Team:          teamColor,
-				Type:          config.RoleTypeOperative,
-				Description:   description,
-				DescriptionKo: descriptionKo,
-				Priority:      99,
-				Icon:          icon,
+			for _, roleDef := range team.cards() {
+				role := createRoleFromConfig(roleDef)
+				shuffled[seat].Role = &role
+				shuffled[seat].Team = role.Team
+				seat++
 			}
-			defaultRole = &basicRole
+			log.Printf("[DEBUG] Dealt %d card(s) to team %s (fill=%d)", team.Size, team.Team, team.Fill)
 		}
+	}
 
-		// Assign default role to remaining players
-		for playerIndex < len(team) {
-			role := createRoleFromConfig(*defaultRole)
-			team[playerIndex].Role = &role
-			log.Printf("[DEBUG] Assigned default role %s to player %s (team=%s)", role.ID, team[playerIndex].ID, teamColor)
-			playerIndex++
-		}
+	if buriedSlot == nil {
+		return nil, nil
 	}
 
-	log.Printf("[DEBUG] Assigned %d roles to %d players in team %s", playerIndex, len(team), teamColor)
-	return nil
+	settleBuriedCard(buriedSlot, players, s.intn)
+	log.Printf("[DEBUG] Buried role %s", buriedSlot.Role.ID)
+	return buriedSlot.Role, nil
 }
 
 // createRoleFromConfig creates a models.Role from a config.RoleDefinition
@@ -405,43 +314,62 @@ func createRoleFromConfig(roleDef config.RoleDefinition) models.Role {
 		team = models.TeamBlue
 	case config.TeamGrey:
 		team = models.TeamGrey
+	case config.TeamZombie:
+		team = models.TeamZombie
 	}
 
-	// Create Role from config
-	return models.Role{
-		ID:            roleDef.ID,
-		Name:          roleDef.Name,
-		NameKo:        roleDef.NameKo,
-		Description:   roleDef.Description,
-		DescriptionKo: roleDef.DescriptionKo,
-		Team:          team,
-		Icon:          roleDef.Icon,
-		IsSpy:         isSpy,
-		IsLeader:      isLeader,
+	// Convert starting conditions from config to models
+	var conditions []models.Condition
+	for _, condition := range roleDef.Conditions {
+		conditions = append(conditions, models.Condition(condition))
+	}
+
+	// Convert card share effect from config to models
+	var onCardShare *models.CardShareEffect
+	if roleDef.OnCardShare != nil {
+		onCardShare = &models.CardShareEffect{
+			ClearConditions: roleDef.OnCardShare.ClearConditions,
+			SwapCards:       roleDef.OnCardShare.SwapCards,
+		}
+		for _, condition := range roleDef.OnCardShare.GiveConditions {
+			onCardShare.GiveConditions = append(onCardShare.GiveConditions, models.Condition(condition))
+		}
+	}
+
+	// Convert declarative win condition from config to models
+	var winCondition *models.WinCondition
+	if roleDef.WinCondition != nil {
+		winCondition = &models.WinCondition{
+			SameRoomAs:        roleDef.WinCondition.SameRoomAs,
+			DifferentRoomFrom: roleDef.WinCondition.DifferentRoomFrom,
+			ExclusiveWith:     roleDef.WinCondition.ExclusiveWith,
+			Expression:        roleDef.WinCondition.Expression,
+		}
 	}
-}
 
-// mapRoleIDToModel maps a role definition ID to a models.Role (legacy, kept for backward compatibility)
-func mapRoleIDToModel(roleID string) models.Role {
-	// Map configuration role IDs to existing models.Role constants
-	switch roleID {
-	case "PRESIDENT":
-		return models.RolePresident
-	case "BOMBER":
-		return models.RoleBomber
-	case "BLUE_SPY":
-		return models.RoleBlueSpy
-	case "RED_SPY":
-		return models.RoleRedSpy
-	case "BLUE_OPERATIVE", "BLUE_TEAM":
-		return models.RoleBlueOperative
-	case "RED_OPERATIVE", "RED_TEAM":
-		return models.RoleRedOperative
-	default:
-		// For custom roles not in models, create a new Role instance
-		// This will need to be enhanced when custom roles are fully supported
-		log.Printf("[WARN] Unknown role ID '%s', using default operative", roleID)
-		return models.RoleBlueOperative
+	// Create Role from config
+	// Name and NameKo are kept for clients that read them; the maps have every locale
+	names, descriptions := roleDef.NameText(), roleDef.DescriptionText()
+	return models.Role{
+		ID:              roleDef.ID,
+		Name:            names.Get(i18n.English),
+		NameKo:          names[i18n.Korean],
+		Description:     descriptions.Get(i18n.English),
+		DescriptionKo:   descriptions[i18n.Korean],
+		Names:           names,
+		Descriptions:    descriptions,
+		Team:            team,
+		Icon:            roleDef.Icon,
+		IsSpy:           isSpy,
+		IsLeader:        isLeader,
+		GoalBinding:     string(roleDef.GoalBinding),
+		Power:           string(roleDef.Power),
+		Conditions:      conditions,
+		OnCardShare:     onCardShare,
+		FinalAction:     models.FinalActionType(roleDef.FinalAction),
+		BackupFor:       roleDef.BackupFor,
+		SwapBuriedRound: roleDef.SwapBuriedRound,
+		WinCondition:    winCondition,
 	}
 }
 
@@ -472,9 +400,22 @@ func AssignRooms(players []*models.Player) {
 	}
 }
 
+// StartGameOptions changes how a game is started
+type StartGameOptions struct {
+	AutoFix bool // Adjust a role selection that cannot be dealt (see AutoFixRoleSelection) instead of failing
+}
+
 // T072: Implement GameService.StartGame
 // Validates room has >=6 players, creates session, assigns teams, roles, and rooms
 func (s *GameService) StartGame(roomCode string) (*models.GameSession, error) {
+	return s.StartGameWithOptions(roomCode, StartGameOptions{})
+}
+
+// StartGameWithOptions starts a game like StartGame
+// If the room's roles cannot be dealt a *RoleAssignmentError is returned and nothing changes,
+// unless opts.AutoFix is set and the fix resolves every problem; the changes made are
+// recorded in the session's RoleAdjustments.
+func (s *GameService) StartGameWithOptions(roomCode string, opts StartGameOptions) (*models.GameSession, error) {
 	// Get room
 	room, err := s.roomStore.Get(roomCode)
 	if err != nil {
@@ -482,7 +423,7 @@ func (s *GameService) StartGame(roomCode string) (*models.GameSession, error) {
 	}
 
 	// Validate room status
-	if room.Status == models.RoomStatusInProgress {
+	if room.IsGameActive() {
 		return nil, errors.New("game already started")
 	}
 
@@ -491,6 +432,18 @@ func (s *GameService) StartGame(roomCode string) (*models.GameSession, error) {
 		return nil, errors.New("insufficient players: minimum 6 required")
 	}
 
+	// Work out the roles before changing anything, so a failed start leaves the lobby as it was
+	var roleConfig *config.RoleConfig
+	var selectedRoles map[string]int
+	var adjustments []*models.RoleCountChange
+	if s.roleLoader != nil || room.CustomRoleConfig != nil {
+		roleConfig, selectedRoles, adjustments, err = s.resolveRoomRoles(room, opts.AutoFix)
+		if err != nil {
+			log.Printf("[WARN] Cannot start game in room %s: %v", roomCode, err)
+			return nil, err
+		}
+	}
+
 	// Create game session
 	sessionID := uuid.New().String()
 	session := &models.GameSession{
@@ -499,31 +452,39 @@ func (s *GameService) StartGame(roomCode string) (*models.GameSession, error) {
 		StartedAt: time.Now(),
 	}
 
+	// Clear conditions left over from a previous game
+	for _, player := range room.Players {
+		player.Conditions = nil
+	}
+
 	// Assign teams (FR-008)
 	AssignTeams(room.Players)
 
-	// Assign roles using configuration
-	// Use room's roleConfigId, default to "standard" if not set
-	roleConfigID := room.RoleConfigID
-	if roleConfigID == "" {
-		roleConfigID = "standard"
-	}
-
-	// Try config-driven assignment if loader is available
-	if s.roleLoader != nil {
-		if err := s.AssignRolesWithConfig(room.Players, roleConfigID, room.SelectedRoles); err != nil {
-			// Fall back to hardcoded assignment if config fails
-			log.Printf("[WARN] Config-driven role assignment failed: %v, falling back to hardcoded", err)
-			AssignRoles(room.Players)
+	// Assign roles from the room's inline role set or its configuration revision;
+	// the built-in roles are only dealt when the server has no role configurations at all
+	if roleConfig != nil {
+		session.BuriedRole, err = s.assignRolesFromConfig(room.Players, roleConfig, selectedRoles)
+		if err != nil {
+			return nil, err
 		}
+		session.RoleAdjustments = adjustments
 	} else {
-		// Fall back to hardcoded assignment if no loader
-		log.Printf("[WARN] No role loader available, using hardcoded role assignment")
 		AssignRoles(room.Players)
 	}
 
-	// Assign rooms (FR-013)
+	// Assign rooms (FR-013) and start each player's room history there
 	AssignRooms(room.Players)
+	session.RecordStartingRooms(room.Players)
+
+	// Apply starting conditions from each player's role (e.g. Shy Guy starts shy)
+	for _, player := range room.Players {
+		if player.Role == nil {
+			continue
+		}
+		for _, condition := range player.Role.Conditions {
+			player.AddCondition(condition)
+		}
+	}
 
 	// Update room status and attach session
 	room.Status = models.RoomStatusInProgress
@@ -594,7 +555,7 @@ func (s *GameService) ResetGame(roomCode string) error {
 	}
 
 	// Validate room status - can only reset a game in progress
-	if room.Status != models.RoomStatusInProgress {
+	if !room.IsGameActive() {
 		return errors.New("game not started")
 	}
 
@@ -606,6 +567,7 @@ func (s *GameService) ResetGame(roomCode string) error {
 		player.Role = nil
 		player.Team = ""
 		player.CurrentRoom = ""
+		player.Conditions = nil
 	}
 
 	// Set room status back to WAITING
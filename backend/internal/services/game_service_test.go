@@ -307,7 +307,7 @@ func TestAssignRooms(t *testing.T) {
 func TestGameService_StartGame(t *testing.T) {
 	t.Run("successfully starts game with 6 players", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		// Create a room with 6 players
 		room := &models.Room{
@@ -365,7 +365,7 @@ func TestGameService_StartGame(t *testing.T) {
 
 	t.Run("fails to start game with less than 6 players", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		room := &models.Room{
 			Code:       "TEST02",
@@ -387,7 +387,7 @@ func TestGameService_StartGame(t *testing.T) {
 
 	t.Run("fails to start game that is already in progress", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		room := &models.Room{
 			Code:       "TEST03",
@@ -416,7 +416,7 @@ func TestGameService_StartGame(t *testing.T) {
 func TestGameService_ResetGame(t *testing.T) {
 	t.Run("successfully resets game in progress", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		// Create a room with a game in progress
 		room := &models.Room{
@@ -486,7 +486,7 @@ func TestGameService_ResetGame(t *testing.T) {
 
 	t.Run("fails to reset game that is not in progress", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		room := &models.Room{
 			Code:       "RESET2",
@@ -507,7 +507,7 @@ func TestGameService_ResetGame(t *testing.T) {
 
 	t.Run("fails to reset game for non-existent room", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
-		gameService := NewGameService(roomStore)
+		gameService := NewGameService(roomStore, nil)
 
 		err := gameService.ResetGame("NOROOM")
 
@@ -137,8 +137,8 @@ func (ls *LeaderService) AssignLeaders(roomCode string) error {
 	}
 
 	// Assign leaders to round state
-	roundState.RedLeaderID = redLeader.ID
-	roundState.BlueLeaderID = blueLeader.ID
+	room.GameSession.RecordLeader(models.RedRoom, redLeader.ID, models.ReasonAssigned)
+	room.GameSession.RecordLeader(models.BlueRoom, blueLeader.ID, models.ReasonAssigned)
 
 	// Update status to ACTIVE
 	roundState.Status = models.RoundStatusActive
@@ -281,11 +281,7 @@ func (ls *LeaderService) TransferLeadership(roomCode, currentLeaderID, newLeader
 	}
 
 	// Update leader assignment
-	if roomColor == models.RedRoom {
-		roundState.RedLeaderID = newLeaderID
-	} else {
-		roundState.BlueLeaderID = newLeaderID
-	}
+	room.GameSession.RecordLeader(roomColor, newLeaderID, models.ReasonVoluntaryTransfer)
 
 	if err := ls.store.Update(room); err != nil {
 		return err
@@ -348,11 +344,7 @@ func (ls *LeaderService) HandleLeaderDisconnect(roomCode, leaderID string) error
 	newLeader := eligiblePlayers[newLeaderIdx]
 
 	// Update leader assignment
-	if roomColor == models.RedRoom {
-		roundState.RedLeaderID = newLeader.ID
-	} else {
-		roundState.BlueLeaderID = newLeader.ID
-	}
+	room.GameSession.RecordLeader(roomColor, newLeader.ID, models.ReasonDisconnection)
 
 	if err := ls.store.Update(room); err != nil {
 		return err
@@ -448,8 +440,6 @@ func (ls *LeaderService) AssignNewLeader(roomCode string, roomColor models.RoomC
 		return nil, errors.New("no active round")
 	}
 
-	roundState := room.GameSession.RoundState
-
 	// Get eligible players in the room (excluding removed leader)
 	var eligiblePlayers []*models.Player
 	for _, player := range room.Players {
@@ -468,11 +458,7 @@ func (ls *LeaderService) AssignNewLeader(roomCode string, roomColor models.RoomC
 	newLeader := eligiblePlayers[newLeaderIdx]
 
 	// Update leader assignment
-	if roomColor == models.RedRoom {
-		roundState.RedLeaderID = newLeader.ID
-	} else {
-		roundState.BlueLeaderID = newLeader.ID
-	}
+	room.GameSession.RecordLeader(roomColor, newLeader.ID, models.ReasonVoteRemoval)
 
 	if err := ls.store.Update(room); err != nil {
 		return nil, err
@@ -513,14 +499,8 @@ func (ls *LeaderService) SetLeader(roomCode string, roomColor models.RoomColor,
 		return nil, errors.New("player not in specified room")
 	}
 
-	roundState := room.GameSession.RoundState
-
 	// Update leader assignment
-	if roomColor == models.RedRoom {
-		roundState.RedLeaderID = newLeader.ID
-	} else {
-		roundState.BlueLeaderID = newLeader.ID
-	}
+	room.GameSession.RecordLeader(roomColor, newLeader.ID, models.ReasonVoteRemoval)
 
 	if err := ls.store.Update(room); err != nil {
 		return nil, err
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+func TestResolveOutcome_Localized(t *testing.T) {
+	room := newOutcomeTestRoom(map[string]models.RoomColor{
+		"PRESIDENT": models.BlueRoom,
+		"BOMBER":    models.RedRoom,
+		"SURVIVOR":  models.BlueRoom,
+		"CLONE":     models.RedRoom,
+	})
+	for _, player := range room.Players {
+		if player.Role.ID == "BOMBER" {
+			player.Role.Names = i18n.Text{i18n.English: "Bomber", i18n.Korean: "폭파범", i18n.Japanese: "爆弾魔"}
+		}
+	}
+	addCardShares(room, [2]string{"CLONE", "SURVIVOR"})
+	outcome := ResolveOutcome(room)
+
+	tests := []struct {
+		locale   string
+		survivor string
+		clone    string
+	}{
+		{i18n.Korean, "폭파범와(과) 다른 방에서 게임을 마쳤습니다", "SURVIVOR이(가) 승리하여 함께 승리했습니다"},
+		{i18n.English, "Ended the game in a different room from Bomber", "SURVIVOR won, so they won too"},
+		{i18n.Japanese, "爆弾魔と別の部屋でゲームを終えました", "SURVIVORが勝利したため、一緒に勝利しました"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			localized := outcome.Localized(tt.locale)
+			if got := findPlayerOutcome(t, localized, "SURVIVOR").Reason; got != tt.survivor {
+				t.Errorf("Expected survivor reason %q, got %q", tt.survivor, got)
+			}
+			if got := findPlayerOutcome(t, localized, "CLONE").Reason; got != tt.clone {
+				t.Errorf("Expected clone reason %q, got %q", tt.clone, got)
+			}
+		})
+	}
+
+	// The stored outcome keeps the default locale
+	if got := findPlayerOutcome(t, outcome, "SURVIVOR").Reason; got != tests[0].survivor {
+		t.Errorf("Expected the stored reason in %s, got %q", i18n.DefaultLocale, got)
+	}
+}
+
+func TestPlayerService_JoinRoomWithLocale(t *testing.T) {
+	tests := []struct {
+		locale   string
+		nickname string
+	}{
+		{i18n.Korean, "플레이어1"},
+		{i18n.English, "Player1"},
+		{i18n.Japanese, "プレイヤー1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			playerService := NewPlayerService(roomStore, nil)
+			room := &models.Room{Code: GenerateRoomCode(), Status: models.RoomStatusWaiting, MaxPlayers: 10}
+			roomStore.Create(room)
+
+			player, err := playerService.JoinRoomWithLocale(room.Code, tt.locale)
+			if err != nil {
+				t.Fatalf("JoinRoomWithLocale failed: %v", err)
+			}
+			if player.Locale != tt.locale {
+				t.Errorf("Expected locale %q, got %q", tt.locale, player.Locale)
+			}
+			if player.Nickname != tt.nickname {
+				t.Errorf("Expected nickname %q, got %q", tt.nickname, player.Nickname)
+			}
+		})
+	}
+}
+
+func TestRoleConfigLoader_Translations(t *testing.T) {
+	president := `{"id": "PRESIDENT", "name": "President", "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1}`
+	tests := []struct {
+		name      string
+		president string
+		wantNames i18n.Text
+		wantErr   string
+	}{
+		{
+			name:      "legacy fields fill in English and Korean",
+			president: `{"id": "PRESIDENT", "name": "President", "nameKo": "대통령", "names": {"ja": "大統領"}, "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1}`,
+			wantNames: i18n.Text{i18n.English: "President", i18n.Korean: "대통령", i18n.Japanese: "大統領"},
+		},
+		{
+			name:      "names alone are enough",
+			president: `{"id": "PRESIDENT", "names": {"ko": "대통령", "ja": "大統領"}, "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1}`,
+			wantNames: i18n.Text{i18n.Korean: "대통령", i18n.Japanese: "大統領"},
+		},
+		{
+			name:      "unsupported locale",
+			president: `{"id": "PRESIDENT", "name": "President", "descriptions": {"fr": "Chef"}, "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1}`,
+			wantErr:   "bury-test.json:7: roles[0].descriptions.fr: unsupported locale 'fr' in descriptions for role 'PRESIDENT'",
+		},
+		{
+			name:      "no name in any locale",
+			president: `{"id": "PRESIDENT", "names": {}, "team": "BLUE", "type": "leader", "count": 1, "minPlayers": 6, "priority": 1}`,
+			wantErr:   "role at index 0 missing name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := strings.Replace(fmt.Sprintf(buryTestConfig, config.BuryNever), president, tt.president, 1)
+			loader, _, err := loadFormatTestConfig(t, "bury-test.json", data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadAll failed: %v", err)
+			}
+
+			roleConfig, _ := loader.Get("bury-test")
+			role := createRoleFromConfig(*roleConfig.FindRole("PRESIDENT"))
+			if fmt.Sprint(role.Names) != fmt.Sprint(tt.wantNames) {
+				t.Errorf("Expected names %v, got %v", tt.wantNames, role.Names)
+			}
+			// Clients reading the single-language fields still get a name
+			if role.Name == "" || role.NameKo != tt.wantNames[i18n.Korean] {
+				t.Errorf("Expected name and nameKo from the names, got %q and %q", role.Name, role.NameKo)
+			}
+		})
+	}
+}
+
+// TestMessageIDs_InCatalog checks every catalog message the services send exists
+func TestMessageIDs_InCatalog(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageID := regexp.MustCompile(`i18n\.Msg\("([^"]+)"[,)]`)
+	found := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range messageID.FindAllStringSubmatch(string(data), -1) {
+			found++
+			if !i18n.HasMessage(match[1]) {
+				t.Errorf("%s: message %q is not in the catalog", file, match[1])
+			}
+		}
+	}
+	if found == 0 {
+		t.Error("Expected the services to send catalog messages")
+	}
+}
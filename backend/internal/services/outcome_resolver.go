@@ -0,0 +1,661 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// winEvaluator decides whether a Grey player achieved their win condition
+// Returns the result and a reason for the reveal, rendered in each reader's locale
+type winEvaluator func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message)
+
+// greyWinEvaluators maps Grey role IDs to their win condition evaluators
+var greyWinEvaluators = map[string]winEvaluator{
+	// Pair-based roles from the official character guide
+	"ROMEO":  sameRoomAsRoles("JULIET", "BOMBER"),
+	"JULIET": sameRoomAsRoles("ROMEO", "BOMBER"),
+	"AHAB":   partnerWithBomberWithoutSelf("MOBY"),
+	"MOBY":   partnerWithBomberWithoutSelf("AHAB"),
+	"RIVAL":  differentRoomFromRole("PRESIDENT"),
+
+	// Single-character relative position roles
+	"SURVIVOR": differentRoomFromRole("BOMBER"),
+	"VICTIM":   sameRoomAsRoles("BOMBER"),
+	"BOMB_BOT": sameRoomAsRoles("BOMBER"),
+	"QUEEN":    allOf(sameRoomAsRoles("PRESIDENT"), differentRoomFromRole("BOMBER")),
+
+	// End-of-game choice roles, judged from the final actions phase
+	"SNIPER":  sniperShot("TARGET", true),
+	"TARGET":  sniperShot("TARGET", false),
+	"DECOY":   sniperShot("DECOY", true),
+	"GAMBLER": gamblerPrediction,
+
+	// Movement-tracking roles are judged from the room history
+	"AGORAPHOBE": neverLeftStartingRoom,
+	"TRAVELER":   hostageMostRounds,
+	"INTERN":     sameRoomAsRoles("PRESIDENT"),
+
+	// Leader-position and card share roles
+	"MASTERMIND": ledBothRooms,
+	"MI6":        cardSharedWithRoles("BOMBER", "PRESIDENT"),
+
+	// Card-swap roles are judged by whoever holds the card at the end
+	"HOT_POTATO": holdsCard(false, i18n.Msg("outcome.hot_potato")),
+	"LEPRECHAUN": holdsCard(true, i18n.Msg("outcome.leprechaun")),
+}
+
+// totalRounds is the number of rounds in a full game
+const totalRounds = 3
+
+// Goal binding modes (mirrors config.GoalBinding)
+const (
+	goalBindingOutcome  = "outcome"  // Wins if the partner wins (Clone)
+	goalBindingGoal     = "goal"     // Adopts the partner's win condition
+	goalBindingOpposite = "opposite" // Wins if the partner loses (Robot)
+)
+
+// outcomeContext holds the end-of-game state shared by all evaluators
+type outcomeContext struct {
+	players     []*models.Player
+	dead        map[string]bool
+	winningTeam models.TeamColor
+	shares      []*models.ShareRecord            // Completed shares in order
+	relations   []*models.Relationship           // In love / in hate links
+	results     map[string]*models.PlayerOutcome // Results of players without goal binding
+	actions     map[string]*models.FinalAction   // End-of-game choices by player ID
+	session     *models.GameSession              // nil when resolving without a game session
+}
+
+// findByRole returns the first player holding the given role, or nil if it is not in play
+// If the card was buried, its backup (President's Daughter, Martyr) stands in for it.
+func (c *outcomeContext) findByRole(roleID string) *models.Player {
+	for _, player := range c.players {
+		if player.Role != nil && player.Role.ID == roleID {
+			return player
+		}
+	}
+	for _, player := range c.players {
+		if player.Role != nil && player.Role.BackupFor == roleID {
+			return player
+		}
+	}
+	return nil
+}
+
+// roomHistory returns the rooms a player entered, in order, or nil without a game session
+func (c *outcomeContext) roomHistory(playerID string) []*models.RoomMove {
+	if c.session == nil {
+		return nil
+	}
+	return c.session.PlayerRoomHistory(playerID)
+}
+
+// findByID returns the player with the given ID, or nil if not found
+func (c *outcomeContext) findByID(playerID string) *models.Player {
+	for _, player := range c.players {
+		if player.ID == playerID {
+			return player
+		}
+	}
+	return nil
+}
+
+// firstSharePartner returns the first player the given player completed a card or color share with
+func (c *outcomeContext) firstSharePartner(playerID string) *models.Player {
+	for _, share := range c.shares {
+		if share.Involves(playerID) {
+			return c.findByID(share.PartnerOf(playerID))
+		}
+	}
+	return nil
+}
+
+// onBindingCycle reports whether following first share partners from a goal-bound player
+// through other goal-bound players leads back to them (e.g. a Clone and a Robot who shared
+// with each other first). No goal on such a cycle can be judged, so every player on it loses.
+func (c *outcomeContext) onBindingCycle(player *models.Player) bool {
+	seen := make(map[string]bool)
+	for next := player; next != nil && c.bound(next); next = c.firstSharePartner(next.ID) {
+		if seen[next.ID] {
+			return next.ID == player.ID
+		}
+		seen[next.ID] = true
+	}
+	return false
+}
+
+// bound reports whether a player's goal depends on their share partner
+// A player who loses through a relationship condition no longer depends on anyone.
+func (c *outcomeContext) bound(player *models.Player) bool {
+	if goalBindingOf(player) == "" {
+		return false
+	}
+	_, lost := c.relationshipLoss(player)
+	return !lost
+}
+
+// ResolveOutcome evaluates the end-of-game result for a room
+// FR: Everyone in the Bomber's room gains the "dead" condition.
+// Red Team wins if the President is dead, otherwise Blue Team wins.
+// A buried President or Bomber is replaced by its backup; with no backup in play
+// a buried Bomber kills no one and a buried President cannot die, so Blue Team wins.
+// Grey players are judged individually by their role's evaluator.
+// Goal-bound roles (Clone, Robot) are resolved in a deferred second pass.
+func ResolveOutcome(room *models.Room) *models.GameOutcome {
+	ctx := &outcomeContext{
+		players: room.Players,
+		dead:    make(map[string]bool),
+		results: make(map[string]*models.PlayerOutcome),
+	}
+	if room.GameSession != nil {
+		ctx.session = room.GameSession
+		ctx.shares = room.GameSession.CompletedShares()
+		ctx.relations = room.GameSession.Relationships
+		if room.GameSession.FinalActions != nil {
+			ctx.actions = room.GameSession.FinalActions.Actions
+		}
+	}
+
+	outcome := &models.GameOutcome{
+		Players:    make([]*models.PlayerOutcome, 0, len(room.Players)),
+		ResolvedAt: time.Now(),
+	}
+	if room.GameSession != nil {
+		outcome.CardTransfers = room.GameSession.CardTransfers
+		outcome.BuriedRole = room.GameSession.BuriedRole
+		outcome.LeaderHistory = room.GameSession.LeaderHistory
+	}
+	for _, player := range room.Players {
+		if action, ok := ctx.actions[player.ID]; ok {
+			outcome.FinalActions = append(outcome.FinalActions, action)
+		}
+	}
+
+	// The Bomber kills everyone in their room at the end of the game,
+	// unless a power (Dr. Boom) already detonated another room and ended the game
+	if bomber := ctx.findByRole(models.RoleBomber.ID); bomber != nil {
+		outcome.BomberRoom = bomber.CurrentRoom
+		outcome.DetonationRoom = bomber.CurrentRoom
+	}
+	if room.GameSession != nil && room.GameSession.EarlyEnd != nil {
+		outcome.EarlyEnd = room.GameSession.EarlyEnd
+		outcome.DetonationRoom = room.GameSession.EarlyEnd.Room
+	}
+	if outcome.DetonationRoom != "" {
+		for _, player := range room.Players {
+			if player.CurrentRoom == outcome.DetonationRoom {
+				ctx.dead[player.ID] = true
+			}
+		}
+	}
+
+	// Red Team wins if the President gains the "dead" condition
+	ctx.winningTeam = models.TeamBlue
+	if president := ctx.findByRole(models.RolePresident.ID); president != nil && ctx.dead[president.ID] {
+		outcome.PresidentDead = true
+		ctx.winningTeam = models.TeamRed
+	}
+
+	// Team Zombie is checked first: if every living player is a zombie, it beats Red and Blue
+	if zombiesOverrun(room.Players, ctx.dead) {
+		ctx.winningTeam = models.TeamZombie
+	}
+	outcome.WinningTeam = ctx.winningTeam
+
+	// First pass: players whose goal does not depend on anyone else
+	for _, player := range room.Players {
+		if goalBindingOf(player) != "" {
+			continue
+		}
+		won, reason := evaluatePlayer(ctx, player)
+		ctx.results[player.ID] = newPlayerOutcome(ctx, player, won, reason)
+	}
+
+	// Second pass: goal-bound players, following binding chains with cycle detection
+	for _, player := range room.Players {
+		if goalBindingOf(player) == "" {
+			continue
+		}
+		won, reason := ctx.resolveBound(player, make(map[string]bool))
+		result := newPlayerOutcome(ctx, player, won, reason)
+		if _, lost := ctx.relationshipLoss(player); lost {
+			ctx.results[player.ID] = result
+			continue
+		}
+		if partner := ctx.firstSharePartner(player.ID); partner != nil {
+			result.BoundToPlayerID = partner.ID
+			result.BoundToNickname = partner.Nickname
+		}
+		ctx.results[player.ID] = result
+	}
+
+	for _, player := range room.Players {
+		outcome.Players = append(outcome.Players, ctx.results[player.ID])
+	}
+
+	return outcome
+}
+
+// newPlayerOutcome builds the reveal entry for a player
+func newPlayerOutcome(ctx *outcomeContext, player *models.Player, won bool, reason i18n.Message) *models.PlayerOutcome {
+	return &models.PlayerOutcome{
+		PlayerID:    player.ID,
+		Nickname:    player.Nickname,
+		Role:        player.Role,
+		Team:        player.Team,
+		FinalRoom:   player.CurrentRoom,
+		Dead:        ctx.dead[player.ID],
+		Won:         won,
+		Reason:      reason.Render(i18n.DefaultLocale),
+		ReasonText:  reason,
+		Conditions:  player.Conditions,
+		RoomHistory: ctx.roomHistory(player.ID),
+	}
+}
+
+// zombiesOverrun reports whether at least one zombie is alive and every living player is a zombie
+func zombiesOverrun(players []*models.Player, dead map[string]bool) bool {
+	livingZombies := 0
+	for _, player := range players {
+		if dead[player.ID] {
+			continue
+		}
+		if player.Team != models.TeamZombie {
+			return false
+		}
+		livingZombies++
+	}
+	return livingZombies > 0
+}
+
+// goalBindingOf returns the player's goal binding mode, or "" if unbound
+// Zombies have no bound goal: being a zombie replaces it.
+func goalBindingOf(player *models.Player) string {
+	if player.Role == nil || player.Team == models.TeamZombie {
+		return ""
+	}
+	return player.Role.GoalBinding
+}
+
+// evaluatePlayer determines whether a single player without goal binding won
+func evaluatePlayer(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+	// Zombie affiliation takes over every other win condition
+	if player.Team == models.TeamZombie {
+		return evaluateGoal(ctx, player, player)
+	}
+	if reason, lost := ctx.relationshipLoss(player); lost {
+		return false, reason
+	}
+	return evaluateGoal(ctx, player, player)
+}
+
+// relationshipLoss reports whether a relationship condition makes the player lose
+// "In love" players lose unless they end in the same room as their partner, and
+// "in hate" players lose unless they end in the other room. Meeting the condition
+// does not win on its own: the player's own goal still decides.
+func (c *outcomeContext) relationshipLoss(player *models.Player) (reason i18n.Message, lost bool) {
+	for _, relation := range c.relations {
+		if !player.HasCondition(relation.Condition) {
+			continue
+		}
+		partner := c.findByID(relation.PartnerOf(player.ID))
+		if partner == nil {
+			continue
+		}
+
+		sameRoom := partner.CurrentRoom == player.CurrentRoom
+		switch {
+		case relation.Condition == models.ConditionInLove && !sameRoom:
+			return i18n.Msg("outcome.love.apart", partner.Nickname), true
+		case relation.Condition == models.ConditionInHate && sameRoom:
+			return i18n.Msg("outcome.hate.together", partner.Nickname), true
+		}
+	}
+	return i18n.Message{}, false
+}
+
+// evaluateGoal judges subject against the win condition of goalOwner
+// For most players the two are the same; a Robot borrows its partner's goal.
+func evaluateGoal(ctx *outcomeContext, goalOwner, subject *models.Player) (bool, i18n.Message) {
+	switch goalOwner.Team {
+	case models.TeamZombie:
+		if ctx.winningTeam == models.TeamZombie {
+			return true, i18n.Msg("outcome.zombies_won")
+		}
+		return false, i18n.Msg("outcome.zombies_lost")
+	case models.TeamRed, models.TeamBlue:
+		won := goalOwner.Team == ctx.winningTeam
+		switch ctx.winningTeam {
+		case models.TeamZombie:
+			return false, i18n.Msg("outcome.zombies_won")
+		case models.TeamRed:
+			return won, i18n.Msg("outcome.red_won")
+		}
+		return won, i18n.Msg("outcome.blue_won")
+	}
+
+	if goalOwner.Role == nil {
+		return false, i18n.Msg("outcome.no_role")
+	}
+
+	// A declarative room goal from the role config takes precedence over built-in evaluators
+	if goalOwner.Role.WinCondition != nil {
+		return declaredWinEvaluator(goalOwner.Role.WinCondition)(ctx, subject)
+	}
+
+	evaluator, ok := greyWinEvaluators[goalOwner.Role.ID]
+	if !ok {
+		return false, i18n.Msg("outcome.not_judged")
+	}
+
+	return evaluator(ctx, subject)
+}
+
+// playerResult returns whether a player won, resolving goal bindings on demand
+func (c *outcomeContext) playerResult(player *models.Player, visiting map[string]bool) (bool, i18n.Message) {
+	if result, ok := c.results[player.ID]; ok {
+		return result.Won, result.ReasonText
+	}
+	return c.resolveBound(player, visiting)
+}
+
+// resolveBound evaluates a goal-bound player (Clone, Robot) against their first card or color
+// share partner
+// visiting tracks the current binding chain; a player reached twice means the chain is a cycle,
+// which can never be satisfied, so every player on it loses.
+func (c *outcomeContext) resolveBound(player *models.Player, visiting map[string]bool) (bool, i18n.Message) {
+	if visiting[player.ID] {
+		return false, i18n.Msg("outcome.binding.cycle")
+	}
+	visiting[player.ID] = true
+	defer delete(visiting, player.ID)
+
+	// Relationship conditions can make a bound player lose as well
+	if reason, lost := c.relationshipLoss(player); lost {
+		return false, reason
+	}
+	if c.onBindingCycle(player) {
+		return false, i18n.Msg("outcome.binding.cycle")
+	}
+
+	partner := c.firstSharePartner(player.ID)
+	if partner == nil {
+		return false, i18n.Msg("outcome.binding.no_partner")
+	}
+
+	switch goalBindingOf(player) {
+	case goalBindingOutcome:
+		won, reason := c.playerResult(partner, visiting)
+		if won {
+			return true, i18n.Msg("outcome.binding.partner_won", partner.Nickname)
+		}
+		return false, i18n.Msg("outcome.binding.partner_lost", partner.Nickname, reason)
+	case goalBindingOpposite:
+		won, reason := c.playerResult(partner, visiting)
+		if won {
+			return false, i18n.Msg("outcome.binding.partner_succeeded", partner.Nickname)
+		}
+		return true, i18n.Msg("outcome.binding.partner_failed", partner.Nickname, reason)
+	case goalBindingGoal:
+		won, reason := c.borrowedGoal(partner, player, visiting)
+		return won, i18n.Msg("outcome.binding.borrowed_goal", partner.Nickname, reason)
+	}
+
+	return false, i18n.Msg("outcome.binding.unknown")
+}
+
+// borrowedGoal judges subject against goalOwner's goal, following chains of Robots
+func (c *outcomeContext) borrowedGoal(goalOwner, subject *models.Player, visiting map[string]bool) (bool, i18n.Message) {
+	switch goalBindingOf(goalOwner) {
+	case goalBindingOutcome, goalBindingOpposite:
+		// A Clone's goal is "my partner wins" (a Robot's "my partner loses"), which is the
+		// same for whoever adopts it
+		return c.playerResult(goalOwner, visiting)
+	case goalBindingGoal:
+		if visiting[goalOwner.ID] {
+			return false, i18n.Msg("outcome.binding.cycle")
+		}
+		next := c.firstSharePartner(goalOwner.ID)
+		if next == nil {
+			return false, i18n.Msg("outcome.binding.goal_unset", goalOwner.Nickname)
+		}
+		visiting[goalOwner.ID] = true
+		defer delete(visiting, goalOwner.ID)
+		return c.borrowedGoal(next, subject, visiting)
+	}
+
+	return evaluateGoal(c, goalOwner, subject)
+}
+
+// sameRoomAsRoles wins if the player ends in the same room as every listed role
+func sameRoomAsRoles(roleIDs ...string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		names := make(i18n.List, 0, len(roleIDs))
+		for _, roleID := range roleIDs {
+			other := ctx.findByRole(roleID)
+			if other == nil {
+				return false, i18n.Msg("outcome.role_missing", roleID)
+			}
+			names = append(names, roleDisplayName(other.Role))
+			if other.CurrentRoom != player.CurrentRoom {
+				return false, i18n.Msg("outcome.different_room", roleDisplayName(other.Role))
+			}
+		}
+		return true, i18n.Msg("outcome.same_room", names)
+	}
+}
+
+// differentRoomFromRole wins if the player ends in a different room from the listed role
+func differentRoomFromRole(roleID string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		other := ctx.findByRole(roleID)
+		if other == nil {
+			return false, i18n.Msg("outcome.role_missing", roleID)
+		}
+		if other.CurrentRoom == player.CurrentRoom {
+			return false, i18n.Msg("outcome.same_room", roleDisplayName(other.Role))
+		}
+		return true, i18n.Msg("outcome.different_room", roleDisplayName(other.Role))
+	}
+}
+
+// partnerWithBomberWithoutSelf wins if the partner role ends with the Bomber and the player does not
+// Used by Ahab and Moby
+func partnerWithBomberWithoutSelf(partnerRoleID string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		partner := ctx.findByRole(partnerRoleID)
+		if partner == nil {
+			return false, i18n.Msg("outcome.role_missing", partnerRoleID)
+		}
+		bomber := ctx.findByRole(models.RoleBomber.ID)
+		if bomber == nil {
+			return false, i18n.Msg("outcome.bomber_missing")
+		}
+
+		partnerName := roleDisplayName(partner.Role)
+		if partner.CurrentRoom != bomber.CurrentRoom {
+			return false, i18n.Msg("outcome.partner_away_from_bomber", partnerName)
+		}
+		if player.CurrentRoom == bomber.CurrentRoom {
+			return false, i18n.Msg("outcome.with_bomber")
+		}
+		return true, i18n.Msg("outcome.partner_with_bomber", partnerName)
+	}
+}
+
+// sniperShot judges a role by whether the Sniper's final shot hit the holder of roleID
+// The Sniper wants to hit the Target; the Target wants to avoid it; the Decoy wants to be hit.
+func sniperShot(roleID string, wantHit bool) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		sniper := ctx.findByRole("SNIPER")
+		if sniper == nil {
+			return !wantHit, i18n.Msg("outcome.sniper.missing")
+		}
+		shot, ok := ctx.actions[sniper.ID]
+		if !ok || shot.TargetID == "" {
+			return !wantHit, i18n.Msg("outcome.sniper.no_shot")
+		}
+
+		victim := ctx.findByID(shot.TargetID)
+		hit := victim != nil && victim.Role != nil && victim.Role.ID == roleID
+		victimName := shot.TargetID
+		if victim != nil {
+			victimName = victim.Nickname
+		}
+		if hit {
+			return wantHit, i18n.Msg("outcome.sniper.hit", victimName, roleDisplayName(victim.Role))
+		}
+		return !wantHit, i18n.Msg("outcome.sniper.shot", victimName)
+	}
+}
+
+// gamblerPrediction wins if the Gambler predicted the winning team
+// A NONE prediction is right when neither Red nor Blue wins (e.g. a zombie overrun).
+func gamblerPrediction(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+	action, ok := ctx.actions[player.ID]
+	if !ok || action.Prediction == "" {
+		return false, i18n.Msg("outcome.prediction.none")
+	}
+	if action.Prediction == models.TeamNone {
+		if ctx.winningTeam != models.TeamRed && ctx.winningTeam != models.TeamBlue {
+			return true, i18n.Msg("outcome.prediction.neither_right")
+		}
+		return false, i18n.Msg("outcome.prediction.neither_wrong")
+	}
+	if action.Prediction == ctx.winningTeam {
+		return true, i18n.Msg("outcome.prediction.right", teamName(action.Prediction))
+	}
+	return false, i18n.Msg("outcome.prediction.wrong", teamName(action.Prediction))
+}
+
+// neverLeftStartingRoom wins if the player was never moved out of their starting room (Agoraphobe)
+func neverLeftStartingRoom(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+	for _, move := range ctx.roomHistory(player.ID) {
+		if move.Reason != models.MoveReasonStart {
+			return false, i18n.Msg("outcome.left_starting_room", move.RoundNumber)
+		}
+	}
+	return true, i18n.Msg("outcome.stayed_in_starting_room")
+}
+
+// hostageMostRounds wins if the player was sent as a hostage in most of the game's rounds (Traveler)
+func hostageMostRounds(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+	rounds := make(map[int]bool)
+	for _, move := range ctx.roomHistory(player.ID) {
+		if move.Reason == models.MoveReasonHostage {
+			rounds[move.RoundNumber] = true
+		}
+	}
+
+	needed := totalRounds/2 + 1
+	reason := i18n.Msg("outcome.hostage_rounds", len(rounds), needed)
+	return len(rounds) >= needed, reason
+}
+
+// ledBothRooms wins if the player leads a room at the end and led the other room earlier (Mastermind)
+func ledBothRooms(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+	if ctx.session == nil {
+		return false, i18n.Msg("outcome.not_leader_at_end")
+	}
+
+	endRoom := ctx.session.LeaderRoomAtEnd(player.ID)
+	if endRoom == "" {
+		return false, i18n.Msg("outcome.not_leader_at_end")
+	}
+
+	for roomColor := range ctx.session.RoomsLedBy(player.ID) {
+		if roomColor != endRoom {
+			return true, i18n.Msg("outcome.led_both_rooms")
+		}
+	}
+	return false, i18n.Msg("outcome.never_led_other_room")
+}
+
+// cardSharedWithRoles wins if the player card shared with every holder of the given roles (MI6)
+func cardSharedWithRoles(roleIDs ...string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		for _, roleID := range roleIDs {
+			other := ctx.findByRole(roleID)
+			if other == nil {
+				return false, i18n.Msg("outcome.role_missing", roleID)
+			}
+			if !ctx.shared(player.ID, other.ID, models.ShareTypeCard) {
+				return false, i18n.Msg("outcome.not_card_shared", roleDisplayName(other.Role))
+			}
+		}
+		return true, i18n.Msg("outcome.card_shared_all")
+	}
+}
+
+// holdsCard gives a fixed result to the final holder of a card that moves between players
+func holdsCard(won bool, reason i18n.Message) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		return won, reason
+	}
+}
+
+// declaredWinEvaluator builds an evaluator from a role's declarative win condition
+func declaredWinEvaluator(condition *models.WinCondition) winEvaluator {
+	var evaluators []winEvaluator
+	if len(condition.SameRoomAs) > 0 {
+		evaluators = append(evaluators, sameRoomAsRoles(condition.SameRoomAs...))
+	}
+	for _, roleID := range condition.DifferentRoomFrom {
+		evaluators = append(evaluators, differentRoomFromRole(roleID))
+	}
+	if condition.Expression != "" {
+		evaluators = append(evaluators, expressionWinEvaluator(condition.Expression))
+	}
+	for _, roleID := range condition.ExclusiveWith {
+		evaluators = append(evaluators, exclusiveWithRole(roleID))
+	}
+	return allOf(evaluators...)
+}
+
+// exclusiveWithRole loses if the rival role also meets its own room goal (Wife, Mistress)
+// Only the rival's room conditions are checked, so two exclusive roles cannot recurse.
+func exclusiveWithRole(roleID string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		rival := ctx.findByRole(roleID)
+		if rival == nil || rival.Role.WinCondition == nil {
+			return true, i18n.Msg("outcome.rival.missing", roleID)
+		}
+
+		roomGoal := *rival.Role.WinCondition
+		roomGoal.ExclusiveWith = nil
+		if won, _ := declaredWinEvaluator(&roomGoal)(ctx, rival); won {
+			return false, i18n.Msg("outcome.rival.met", roleDisplayName(rival.Role))
+		}
+		return true, i18n.Msg("outcome.rival.not_met", roleDisplayName(rival.Role))
+	}
+}
+
+// allOf wins only if every evaluator wins; the first failure explains the loss
+func allOf(evaluators ...winEvaluator) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		reasons := make(i18n.List, 0, len(evaluators))
+		for _, evaluate := range evaluators {
+			won, reason := evaluate(ctx, player)
+			if !won {
+				return false, reason
+			}
+			reasons = append(reasons, reason)
+		}
+		return true, i18n.Msg("list", reasons)
+	}
+}
+
+// roleDisplayName returns the role name in every locale, to render in a reader's locale
+func roleDisplayName(role *models.Role) i18n.Text {
+	if role == nil {
+		return i18n.Text{}
+	}
+	return role.NameText()
+}
+
+// teamName returns a team's name, to render in a reader's locale
+func teamName(team models.TeamColor) i18n.Message {
+	return i18n.Msg("team." + string(team))
+}
@@ -0,0 +1,981 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// newOutcomeTestRoom builds a room where each entry maps a role ID to a final room
+func newOutcomeTestRoom(placements map[string]models.RoomColor) *models.Room {
+	room := &models.Room{Code: "TEST01"}
+	for roleID, roomColor := range placements {
+		team := models.TeamGrey
+		switch roleID {
+		case "PRESIDENT", "BLUE_TEAM":
+			team = models.TeamBlue
+		case "BOMBER", "RED_TEAM":
+			team = models.TeamRed
+		}
+		role := &models.Role{ID: roleID, Team: team}
+		switch roleID {
+		case "CLONE":
+			role.GoalBinding = "outcome"
+		case "ROBOT":
+			role.GoalBinding = "opposite"
+		case "MIMIC": // A custom role adopting its partner's goal
+			role.GoalBinding = "goal"
+		}
+		room.Players = append(room.Players, &models.Player{
+			ID:          "player-" + roleID,
+			Nickname:    roleID,
+			Role:        role,
+			Team:        team,
+			CurrentRoom: roomColor,
+		})
+	}
+	return room
+}
+
+// addCardShares records accepted card shares between role holders, in order
+func addCardShares(room *models.Room, pairs ...[2]string) {
+	if room.GameSession == nil {
+		room.GameSession = &models.GameSession{}
+	}
+	for _, pair := range pairs {
+		room.GameSession.Shares = append(room.GameSession.Shares, &models.ShareRecord{
+			Type:        models.ShareTypeCard,
+			InitiatorID: "player-" + pair[0],
+			TargetID:    "player-" + pair[1],
+			Status:      models.ShareStatusAccepted,
+		})
+	}
+}
+
+// findPlayerOutcome returns the outcome for the player holding a role
+func findPlayerOutcome(t *testing.T, outcome *models.GameOutcome, roleID string) *models.PlayerOutcome {
+	t.Helper()
+	for _, result := range outcome.Players {
+		if result.Role != nil && result.Role.ID == roleID {
+			return result
+		}
+	}
+	t.Fatalf("no outcome for role %s", roleID)
+	return nil
+}
+
+func TestResolveOutcome_TeamResult(t *testing.T) {
+	t.Run("President in Bomber's room - Red Team wins", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.RedRoom,
+			"BOMBER":    models.RedRoom,
+			"BLUE_TEAM": models.BlueRoom,
+			"RED_TEAM":  models.BlueRoom,
+		})
+
+		outcome := ResolveOutcome(room)
+
+		if outcome.WinningTeam != models.TeamRed {
+			t.Errorf("Expected RED to win, got %s", outcome.WinningTeam)
+		}
+		if !outcome.PresidentDead {
+			t.Error("Expected President to be dead")
+		}
+		if !findPlayerOutcome(t, outcome, "RED_TEAM").Won {
+			t.Error("Expected Red Team member to win")
+		}
+		if findPlayerOutcome(t, outcome, "BLUE_TEAM").Won {
+			t.Error("Expected Blue Team member to lose")
+		}
+	})
+
+	t.Run("President in other room - Blue Team wins", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.BlueRoom,
+			"BOMBER":    models.RedRoom,
+		})
+
+		outcome := ResolveOutcome(room)
+
+		if outcome.WinningTeam != models.TeamBlue {
+			t.Errorf("Expected BLUE to win, got %s", outcome.WinningTeam)
+		}
+		if !findPlayerOutcome(t, outcome, "BOMBER").Dead {
+			t.Error("Expected Bomber to gain the dead condition")
+		}
+	})
+}
+
+func TestResolveOutcome_PairBasedGreyRoles(t *testing.T) {
+	tests := []struct {
+		name       string
+		placements map[string]models.RoomColor
+		roleID     string
+		expectWin  bool
+	}{
+		{
+			name: "Romeo with Juliet and Bomber wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"ROMEO": models.RedRoom, "JULIET": models.RedRoom,
+			},
+			roleID:    "ROMEO",
+			expectWin: true,
+		},
+		{
+			name: "Juliet without Bomber loses",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"ROMEO": models.BlueRoom, "JULIET": models.BlueRoom,
+			},
+			roleID:    "JULIET",
+			expectWin: false,
+		},
+		{
+			name: "Ahab wins when Moby is with Bomber and Ahab is not",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"AHAB": models.BlueRoom, "MOBY": models.RedRoom,
+			},
+			roleID:    "AHAB",
+			expectWin: true,
+		},
+		{
+			name: "Moby loses when Moby is with Bomber",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"AHAB": models.BlueRoom, "MOBY": models.RedRoom,
+			},
+			roleID:    "MOBY",
+			expectWin: false,
+		},
+		{
+			name: "Ahab loses when both are with Bomber",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"AHAB": models.RedRoom, "MOBY": models.RedRoom,
+			},
+			roleID:    "AHAB",
+			expectWin: false,
+		},
+		{
+			name: "Rival wins away from President",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"RIVAL": models.RedRoom,
+			},
+			roleID:    "RIVAL",
+			expectWin: true,
+		},
+		{
+			name: "Rival loses with President",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"RIVAL": models.BlueRoom,
+			},
+			roleID:    "RIVAL",
+			expectWin: false,
+		},
+		{
+			name: "Romeo loses when Juliet is not in play",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"ROMEO": models.RedRoom,
+			},
+			roleID:    "ROMEO",
+			expectWin: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome := ResolveOutcome(newOutcomeTestRoom(tt.placements))
+
+			result := findPlayerOutcome(t, outcome, tt.roleID)
+			if result.Won != tt.expectWin {
+				t.Errorf("Expected won=%v for %s, got %v (%s)", tt.expectWin, tt.roleID, result.Won, result.Reason)
+			}
+			if result.Reason == "" {
+				t.Error("Expected a reason for the reveal")
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_GoalBinding(t *testing.T) {
+	tests := []struct {
+		name       string
+		placements map[string]models.RoomColor
+		shares     [][2]string
+		roleID     string
+		expectWin  bool
+		expectBond string
+	}{
+		{
+			name: "Clone wins with first card share partner",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"BLUE_TEAM": models.BlueRoom, "RED_TEAM": models.RedRoom, "CLONE": models.RedRoom,
+			},
+			shares:     [][2]string{{"CLONE", "BLUE_TEAM"}, {"CLONE", "RED_TEAM"}},
+			roleID:     "CLONE",
+			expectWin:  true,
+			expectBond: "player-BLUE_TEAM",
+		},
+		{
+			name: "Clone loses with losing partner",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"RED_TEAM": models.RedRoom, "CLONE": models.BlueRoom,
+			},
+			shares:     [][2]string{{"RED_TEAM", "CLONE"}},
+			roleID:     "CLONE",
+			expectWin:  false,
+			expectBond: "player-RED_TEAM",
+		},
+		{
+			name: "Clone without card share loses",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"CLONE": models.BlueRoom,
+			},
+			roleID:    "CLONE",
+			expectWin: false,
+		},
+		{
+			name: "Robot wins when its partner fails",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"RED_TEAM": models.RedRoom, "ROBOT": models.BlueRoom,
+			},
+			shares:     [][2]string{{"ROBOT", "RED_TEAM"}},
+			roleID:     "ROBOT",
+			expectWin:  true,
+			expectBond: "player-RED_TEAM",
+		},
+		{
+			name: "Robot loses when its partner wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"BLUE_TEAM": models.BlueRoom, "ROBOT": models.BlueRoom,
+			},
+			shares:     [][2]string{{"BLUE_TEAM", "ROBOT"}},
+			roleID:     "ROBOT",
+			expectWin:  false,
+			expectBond: "player-BLUE_TEAM",
+		},
+		{
+			name: "Robot bound to a Clone wins when the Clone's partner fails",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"RED_TEAM": models.RedRoom, "CLONE": models.BlueRoom, "ROBOT": models.RedRoom,
+			},
+			shares:     [][2]string{{"CLONE", "RED_TEAM"}, {"ROBOT", "CLONE"}},
+			roleID:     "ROBOT",
+			expectWin:  true,
+			expectBond: "player-CLONE",
+		},
+		{
+			name: "Mimic adopts Survivor goal and is judged by its own room",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"SURVIVOR": models.RedRoom, "MIMIC": models.BlueRoom,
+			},
+			shares:     [][2]string{{"MIMIC", "SURVIVOR"}},
+			roleID:     "MIMIC",
+			expectWin:  true,
+			expectBond: "player-SURVIVOR",
+		},
+		{
+			name: "Mimic copying Clone follows Clone's partner",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"BLUE_TEAM": models.BlueRoom, "CLONE": models.BlueRoom, "MIMIC": models.RedRoom,
+			},
+			shares:     [][2]string{{"CLONE", "BLUE_TEAM"}, {"MIMIC", "CLONE"}},
+			roleID:     "MIMIC",
+			expectWin:  true,
+			expectBond: "player-CLONE",
+		},
+		{
+			name: "Clone bound to a Robot on a cycle loses",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"CLONE": models.BlueRoom, "ROBOT": models.BlueRoom,
+			},
+			shares:     [][2]string{{"CLONE", "ROBOT"}},
+			roleID:     "CLONE",
+			expectWin:  false,
+			expectBond: "player-ROBOT",
+		},
+		{
+			name: "Robot bound to a Clone on a cycle loses",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"CLONE": models.BlueRoom, "ROBOT": models.BlueRoom,
+			},
+			shares:     [][2]string{{"CLONE", "ROBOT"}},
+			roleID:     "ROBOT",
+			expectWin:  false,
+			expectBond: "player-CLONE",
+		},
+		{
+			name: "Robot bound to a Clone on someone else's cycle wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+				"CLONE": models.BlueRoom, "MIMIC": models.BlueRoom, "ROBOT": models.RedRoom,
+			},
+			shares:     [][2]string{{"CLONE", "MIMIC"}, {"ROBOT", "CLONE"}},
+			roleID:     "ROBOT",
+			expectWin:  true,
+			expectBond: "player-CLONE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(tt.placements)
+			addCardShares(room, tt.shares...)
+
+			outcome := ResolveOutcome(room)
+
+			result := findPlayerOutcome(t, outcome, tt.roleID)
+			if result.Won != tt.expectWin {
+				t.Errorf("Expected won=%v for %s, got %v (%s)", tt.expectWin, tt.roleID, result.Won, result.Reason)
+			}
+			if result.BoundToPlayerID != tt.expectBond {
+				t.Errorf("Expected binding to %q, got %q", tt.expectBond, result.BoundToPlayerID)
+			}
+		})
+	}
+
+	t.Run("Color shares bind goals", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+			"BLUE_TEAM": models.BlueRoom, "CLONE": models.BlueRoom,
+		})
+		room.GameSession = &models.GameSession{Shares: []*models.ShareRecord{{
+			Type:        models.ShareTypeColor,
+			InitiatorID: "player-CLONE",
+			TargetID:    "player-BLUE_TEAM",
+			Status:      models.ShareStatusAccepted,
+		}}}
+
+		result := findPlayerOutcome(t, ResolveOutcome(room), "CLONE")
+		if !result.Won || result.BoundToPlayerID != "player-BLUE_TEAM" {
+			t.Errorf("Expected Clone bound to its color share partner to win, got won=%v bound=%q", result.Won, result.BoundToPlayerID)
+		}
+	})
+}
+
+// addRelationship links two role holders with a paired condition
+func addRelationship(room *models.Room, condition models.Condition, roleA, roleB string) {
+	if room.GameSession == nil {
+		room.GameSession = &models.GameSession{}
+	}
+	for _, player := range room.Players {
+		if player.Role.ID == roleA || player.Role.ID == roleB {
+			player.AddCondition(condition)
+		}
+	}
+	room.GameSession.Relationships = append(room.GameSession.Relationships, &models.Relationship{
+		Condition: condition,
+		PlayerIDs: [2]string{"player-" + roleA, "player-" + roleB},
+	})
+}
+
+func TestResolveOutcome_Relationships(t *testing.T) {
+	tests := []struct {
+		name       string
+		placements map[string]models.RoomColor
+		condition  models.Condition
+		roleID     string
+		expectWin  bool
+		expectTeam models.TeamColor
+	}{
+		{
+			name: "President in love with Bomber loses in same room because Red Team wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.RedRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInLove,
+			roleID:     "PRESIDENT",
+			expectWin:  false,
+			expectTeam: models.TeamRed,
+		},
+		{
+			name: "Bomber in love with President wins in same room with Red Team",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.RedRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInLove,
+			roleID:     "BOMBER",
+			expectWin:  true,
+			expectTeam: models.TeamRed,
+		},
+		{
+			name: "President in love loses in different rooms even though Blue Team wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInLove,
+			roleID:     "PRESIDENT",
+			expectWin:  false,
+			expectTeam: models.TeamBlue,
+		},
+		{
+			name: "President in hate wins in different rooms with Blue Team",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInHate,
+			roleID:     "PRESIDENT",
+			expectWin:  true,
+			expectTeam: models.TeamBlue,
+		},
+		{
+			name: "Bomber in hate loses in different rooms because Blue Team wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInHate,
+			roleID:     "BOMBER",
+			expectWin:  false,
+			expectTeam: models.TeamBlue,
+		},
+		{
+			name: "Bomber in hate loses in same room even though Red Team wins",
+			placements: map[string]models.RoomColor{
+				"PRESIDENT": models.RedRoom, "BOMBER": models.RedRoom,
+			},
+			condition:  models.ConditionInHate,
+			roleID:     "BOMBER",
+			expectWin:  false,
+			expectTeam: models.TeamRed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(tt.placements)
+			addRelationship(room, tt.condition, "PRESIDENT", "BOMBER")
+
+			outcome := ResolveOutcome(room)
+
+			if outcome.WinningTeam != tt.expectTeam {
+				t.Errorf("Expected %s to win, got %s", tt.expectTeam, outcome.WinningTeam)
+			}
+			result := findPlayerOutcome(t, outcome, tt.roleID)
+			if result.Won != tt.expectWin {
+				t.Errorf("Expected won=%v, got %v (%s)", tt.expectWin, result.Won, result.Reason)
+			}
+		})
+	}
+
+	t.Run("cancelled condition restores original goal", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.BlueRoom, "BOMBER": models.RedRoom,
+		})
+		addRelationship(room, models.ConditionInLove, "PRESIDENT", "BOMBER")
+		addRelationship(room, models.ConditionInHate, "PRESIDENT", "BOMBER")
+
+		result := findPlayerOutcome(t, ResolveOutcome(room), "PRESIDENT")
+		if !result.Won || len(result.Conditions) != 0 {
+			t.Errorf("Expected President to win with no conditions, got won=%v conditions=%v", result.Won, result.Conditions)
+		}
+	})
+}
+
+func TestResolveOutcome_CardSwap(t *testing.T) {
+	room := newOutcomeTestRoom(map[string]models.RoomColor{
+		"PRESIDENT":  models.BlueRoom,
+		"BOMBER":     models.RedRoom,
+		"HOT_POTATO": models.RedRoom,
+		"LEPRECHAUN": models.BlueRoom,
+		"BLUE_TEAM":  models.RedRoom,
+	})
+
+	// The Hot Potato and Blue Team players swapped cards during a card share
+	potato, blue := findPlayer(room, "player-HOT_POTATO"), findPlayer(room, "player-BLUE_TEAM")
+	potato.Role, blue.Role = blue.Role, potato.Role
+	potato.Team, blue.Team = blue.Team, potato.Team
+	room.GameSession = &models.GameSession{CardTransfers: []*models.CardTransfer{
+		{RoleID: "HOT_POTATO", FromPlayerID: potato.ID, ToPlayerID: blue.ID},
+		{RoleID: "BLUE_TEAM", FromPlayerID: blue.ID, ToPlayerID: potato.ID},
+	}}
+
+	outcome := ResolveOutcome(room)
+
+	if result := findPlayerOutcome(t, outcome, "HOT_POTATO"); result.PlayerID != blue.ID || result.Won {
+		t.Errorf("Expected final Hot Potato holder %s to lose, got player=%s won=%v", blue.ID, result.PlayerID, result.Won)
+	}
+	if result := findPlayerOutcome(t, outcome, "BLUE_TEAM"); result.PlayerID != potato.ID || !result.Won {
+		t.Errorf("Expected new Blue Team holder %s to win with Blue, got player=%s won=%v", potato.ID, result.PlayerID, result.Won)
+	}
+	if result := findPlayerOutcome(t, outcome, "LEPRECHAUN"); !result.Won {
+		t.Errorf("Expected Leprechaun holder to win, got %s", result.Reason)
+	}
+	if len(outcome.CardTransfers) != 2 {
+		t.Errorf("Expected card history in the reveal, got %d transfers", len(outcome.CardTransfers))
+	}
+}
+
+func TestResolveOutcome_Zombies(t *testing.T) {
+	tests := []struct {
+		name        string
+		zombies     []string
+		wantWinner  models.TeamColor
+		wantZombies bool
+	}{
+		// The Bomber's room (RED_ROOM) dies; BLUE_ROOM holds PRESIDENT and BLUE_TEAM
+		{name: "every living player is a zombie", zombies: []string{"PRESIDENT", "BLUE_TEAM"}, wantWinner: models.TeamZombie, wantZombies: true},
+		{name: "a living non-zombie survives", zombies: []string{"BLUE_TEAM"}, wantWinner: models.TeamBlue, wantZombies: false},
+		{name: "dead zombies do not count", zombies: []string{"RED_TEAM"}, wantWinner: models.TeamBlue, wantZombies: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BLUE_TEAM": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+				"RED_TEAM":  models.RedRoom,
+			})
+			for _, roleID := range tt.zombies {
+				findPlayer(room, "player-"+roleID).Team = models.TeamZombie
+			}
+
+			outcome := ResolveOutcome(room)
+
+			if outcome.WinningTeam != tt.wantWinner {
+				t.Errorf("Expected %s to win, got %s", tt.wantWinner, outcome.WinningTeam)
+			}
+			for _, roleID := range tt.zombies {
+				if result := findPlayerOutcome(t, outcome, roleID); result.Won != tt.wantZombies {
+					t.Errorf("Expected zombie %s won=%v, got %v (%s)", roleID, tt.wantZombies, result.Won, result.Reason)
+				}
+			}
+			if tt.wantWinner == models.TeamZombie {
+				if result := findPlayerOutcome(t, outcome, "BOMBER"); result.Won {
+					t.Error("Expected Red Team to lose to the zombies")
+				}
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_FinalActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		shot       string // role ID the Sniper shot, "" for no shot
+		prediction models.TeamColor
+		wantWon    map[string]bool
+	}{
+		{name: "sniper hits target", shot: "TARGET", prediction: models.TeamBlue, wantWon: map[string]bool{"SNIPER": true, "TARGET": false, "DECOY": false, "GAMBLER": true}},
+		{name: "sniper hits decoy", shot: "DECOY", prediction: models.TeamRed, wantWon: map[string]bool{"SNIPER": false, "TARGET": true, "DECOY": true, "GAMBLER": false}},
+		{name: "sniper hits someone else", shot: "PRESIDENT", wantWon: map[string]bool{"SNIPER": false, "TARGET": true, "DECOY": false, "GAMBLER": false}},
+		{name: "no shot taken", wantWon: map[string]bool{"SNIPER": false, "TARGET": true, "DECOY": false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+				"SNIPER":    models.RedRoom,
+				"TARGET":    models.BlueRoom,
+				"DECOY":     models.RedRoom,
+				"GAMBLER":   models.BlueRoom,
+			})
+			actions := map[string]*models.FinalAction{}
+			if tt.shot != "" {
+				actions["player-SNIPER"] = &models.FinalAction{PlayerID: "player-SNIPER", Type: models.FinalActionShoot, TargetID: "player-" + tt.shot}
+			}
+			if tt.prediction != "" {
+				actions["player-GAMBLER"] = &models.FinalAction{PlayerID: "player-GAMBLER", Type: models.FinalActionPredict, Prediction: tt.prediction}
+			}
+			room.GameSession = &models.GameSession{FinalActions: &models.FinalActionsPhase{Actions: actions}}
+
+			outcome := ResolveOutcome(room)
+
+			for roleID, want := range tt.wantWon {
+				if result := findPlayerOutcome(t, outcome, roleID); result.Won != want {
+					t.Errorf("Expected %s won=%v, got %v (%s)", roleID, want, result.Won, result.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_GamblerPredictsNeither(t *testing.T) {
+	tests := []struct {
+		name       string
+		zombies    []string
+		prediction models.TeamColor
+		wantWon    bool
+	}{
+		// The Bomber's room (RED_ROOM) dies, taking the Gambler with it
+		{name: "zombie overrun", zombies: []string{"PRESIDENT", "BLUE_TEAM"}, prediction: models.TeamNone, wantWon: true},
+		{name: "blue team wins", prediction: models.TeamNone, wantWon: false},
+		{name: "predicting blue in a zombie overrun", zombies: []string{"PRESIDENT", "BLUE_TEAM"}, prediction: models.TeamBlue, wantWon: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BLUE_TEAM": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+				"GAMBLER":   models.RedRoom,
+			})
+			for _, roleID := range tt.zombies {
+				findPlayer(room, "player-"+roleID).Team = models.TeamZombie
+			}
+			actions := map[string]*models.FinalAction{
+				"player-GAMBLER": {PlayerID: "player-GAMBLER", Type: models.FinalActionPredict, Prediction: tt.prediction},
+			}
+			room.GameSession = &models.GameSession{FinalActions: &models.FinalActionsPhase{Actions: actions}}
+
+			outcome := ResolveOutcome(room)
+
+			if result := findPlayerOutcome(t, outcome, "GAMBLER"); result.Won != tt.wantWon {
+				t.Errorf("Expected GAMBLER won=%v, got %v (%s)", tt.wantWon, result.Won, result.Reason)
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_BuriedLeader(t *testing.T) {
+	t.Run("martyr detonates for a buried bomber", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.BlueRoom,
+			"MARTYR":    models.BlueRoom,
+		})
+		findPlayer(room, "player-MARTYR").Role.BackupFor = "BOMBER"
+		buried := &models.Role{ID: "BOMBER", Team: models.TeamRed, IsLeader: true}
+		room.GameSession = &models.GameSession{BuriedRole: buried}
+
+		outcome := ResolveOutcome(room)
+
+		if outcome.WinningTeam != models.TeamRed {
+			t.Errorf("Expected RED to win, got %s", outcome.WinningTeam)
+		}
+		if outcome.BuriedRole != buried {
+			t.Errorf("Expected the buried card to be revealed, got %+v", outcome.BuriedRole)
+		}
+	})
+
+	t.Run("daughter survives for a buried president", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENTS_DAUGHTER": models.BlueRoom,
+			"BOMBER":              models.RedRoom,
+		})
+		findPlayer(room, "player-PRESIDENTS_DAUGHTER").Role.BackupFor = "PRESIDENT"
+
+		outcome := ResolveOutcome(room)
+
+		if outcome.WinningTeam != models.TeamBlue {
+			t.Errorf("Expected BLUE to win, got %s", outcome.WinningTeam)
+		}
+	})
+
+	t.Run("daughter dies for a buried president", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENTS_DAUGHTER": models.RedRoom,
+			"BOMBER":              models.RedRoom,
+		})
+		findPlayer(room, "player-PRESIDENTS_DAUGHTER").Role.BackupFor = "PRESIDENT"
+
+		outcome := ResolveOutcome(room)
+
+		if outcome.WinningTeam != models.TeamRed || !outcome.PresidentDead {
+			t.Errorf("Expected RED to win with the daughter dead, got %s", outcome.WinningTeam)
+		}
+	})
+}
+
+func TestResolveOutcome_RoomHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		roleID  string
+		moves   []int // Rounds in which the player was sent as a hostage
+		wantWon bool
+	}{
+		{name: "agoraphobe never moved", roleID: "AGORAPHOBE", wantWon: true},
+		{name: "agoraphobe sent as hostage", roleID: "AGORAPHOBE", moves: []int{2}, wantWon: false},
+		{name: "traveler sent in two rounds", roleID: "TRAVELER", moves: []int{1, 3}, wantWon: true},
+		{name: "traveler sent in one round", roleID: "TRAVELER", moves: []int{2}, wantWon: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+				tt.roleID:   models.RedRoom,
+			})
+			session := &models.GameSession{}
+			session.RecordStartingRooms(room.Players)
+			player := findPlayer(room, "player-"+tt.roleID)
+			for _, round := range tt.moves {
+				session.CurrentRound = round
+				to := models.BlueRoom
+				if player.CurrentRoom == models.BlueRoom {
+					to = models.RedRoom
+				}
+				session.MovePlayer(player, to, models.MoveReasonHostage)
+			}
+			room.GameSession = session
+
+			outcome := ResolveOutcome(room)
+
+			result := findPlayerOutcome(t, outcome, tt.roleID)
+			if result.Won != tt.wantWon {
+				t.Errorf("Expected won=%v, got %v (%s)", tt.wantWon, result.Won, result.Reason)
+			}
+			if len(result.RoomHistory) != len(tt.moves)+1 {
+				t.Errorf("Expected %d room history entries, got %d", len(tt.moves)+1, len(result.RoomHistory))
+			}
+		})
+	}
+
+	t.Run("intern with the president", func(t *testing.T) {
+		room := newOutcomeTestRoom(map[string]models.RoomColor{
+			"PRESIDENT": models.BlueRoom,
+			"BOMBER":    models.RedRoom,
+			"INTERN":    models.BlueRoom,
+		})
+		if result := findPlayerOutcome(t, ResolveOutcome(room), "INTERN"); !result.Won {
+			t.Errorf("Expected intern to win, got %s", result.Reason)
+		}
+	})
+}
+
+func TestResolveOutcome_Mastermind(t *testing.T) {
+	tests := []struct {
+		name    string
+		terms   []models.RoomColor // Rooms the Mastermind led, in order
+		wantWon bool
+	}{
+		{name: "never led", wantWon: false},
+		{name: "led one room only", terms: []models.RoomColor{models.RedRoom}, wantWon: false},
+		{name: "led both rooms and leads at the end", terms: []models.RoomColor{models.BlueRoom, models.RedRoom}, wantWon: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT":  models.BlueRoom,
+				"BOMBER":     models.RedRoom,
+				"MASTERMIND": models.RedRoom,
+			})
+			session := &models.GameSession{RoundState: &models.RoundState{RoundNumber: 1}}
+			session.RecordLeader(models.RedRoom, "player-BOMBER", models.ReasonAssigned)
+			session.RecordLeader(models.BlueRoom, "player-PRESIDENT", models.ReasonAssigned)
+			for i, roomColor := range tt.terms {
+				session.RoundState.RoundNumber = i + 1
+				session.RecordLeader(roomColor, "player-MASTERMIND", models.ReasonVoteRemoval)
+			}
+			room.GameSession = session
+
+			outcome := ResolveOutcome(room)
+
+			if result := findPlayerOutcome(t, outcome, "MASTERMIND"); result.Won != tt.wantWon {
+				t.Errorf("Expected won=%v, got %v (%s)", tt.wantWon, result.Won, result.Reason)
+			}
+			if len(outcome.LeaderHistory) != len(tt.terms)+2 {
+				t.Errorf("Expected %d leader history entries, got %d", len(tt.terms)+2, len(outcome.LeaderHistory))
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_MI6(t *testing.T) {
+	tests := []struct {
+		name    string
+		shares  [][2]string
+		wantWon bool
+	}{
+		{name: "shared with both", shares: [][2]string{{"MI6", "BOMBER"}, {"PRESIDENT", "MI6"}}, wantWon: true},
+		{name: "shared with bomber only", shares: [][2]string{{"MI6", "BOMBER"}}, wantWon: false},
+		{name: "no shares", wantWon: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newOutcomeTestRoom(map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+				"MI6":       models.RedRoom,
+			})
+			addCardShares(room, tt.shares...)
+
+			if result := findPlayerOutcome(t, ResolveOutcome(room), "MI6"); result.Won != tt.wantWon {
+				t.Errorf("Expected won=%v, got %v (%s)", tt.wantWon, result.Won, result.Reason)
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_DeclarativeWinConditions(t *testing.T) {
+	winConditions := map[string]*models.WinCondition{
+		"BUTLER":   {SameRoomAs: []string{"MAID", "PRESIDENT"}},
+		"MAID":     {SameRoomAs: []string{"BUTLER", "PRESIDENT"}},
+		"WIFE":     {SameRoomAs: []string{"PRESIDENT"}, ExclusiveWith: []string{"MISTRESS"}},
+		"MISTRESS": {SameRoomAs: []string{"PRESIDENT"}, ExclusiveWith: []string{"WIFE"}},
+		"LONER":    {DifferentRoomFrom: []string{"PRESIDENT", "BOMBER"}},
+	}
+
+	tests := []struct {
+		name       string
+		placements map[string]models.RoomColor
+		wantWon    map[string]bool
+	}{
+		{
+			name:       "butler and maid with the president",
+			placements: map[string]models.RoomColor{"BUTLER": models.BlueRoom, "MAID": models.BlueRoom},
+			wantWon:    map[string]bool{"BUTLER": true, "MAID": true},
+		},
+		{
+			name:       "maid away from the butler",
+			placements: map[string]models.RoomColor{"BUTLER": models.BlueRoom, "MAID": models.RedRoom},
+			wantWon:    map[string]bool{"BUTLER": false, "MAID": false},
+		},
+		{
+			name:       "wife alone with the president",
+			placements: map[string]models.RoomColor{"WIFE": models.BlueRoom, "MISTRESS": models.RedRoom},
+			wantWon:    map[string]bool{"WIFE": true, "MISTRESS": false},
+		},
+		{
+			name:       "wife and mistress both with the president",
+			placements: map[string]models.RoomColor{"WIFE": models.BlueRoom, "MISTRESS": models.BlueRoom},
+			wantWon:    map[string]bool{"WIFE": false, "MISTRESS": false},
+		},
+		{
+			name:       "different room from two roles cannot be met",
+			placements: map[string]models.RoomColor{"LONER": models.RedRoom},
+			wantWon:    map[string]bool{"LONER": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placements := map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+			}
+			for roleID, roomColor := range tt.placements {
+				placements[roleID] = roomColor
+			}
+			room := newOutcomeTestRoom(placements)
+			for roleID := range tt.placements {
+				findPlayer(room, "player-"+roleID).Role.WinCondition = winConditions[roleID]
+			}
+
+			outcome := ResolveOutcome(room)
+
+			for roleID, want := range tt.wantWon {
+				if result := findPlayerOutcome(t, outcome, roleID); result.Won != want {
+					t.Errorf("Expected %s won=%v, got %v (%s)", roleID, want, result.Won, result.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveOutcome_WinExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		placements map[string]models.RoomColor
+		shares     [][2]string
+		conditions []models.Condition
+		wantWon    bool
+	}{
+		{
+			name:       "same room as the president but not the bomber",
+			expression: `sameRoom("PRESIDENT") and not sameRoom("BOMBER")`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.BlueRoom},
+			wantWon:    true,
+		},
+		{
+			name:       "role fields",
+			expression: `role("PRESIDENT").room == me.room and role("PRESIDENT").team == "BLUE"`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			wantWon:    false,
+		},
+		{
+			name:       "missing role is nil",
+			expression: `not inPlay("DOCTOR") and role("DOCTOR") == nil`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			wantWon:    true,
+		},
+		{
+			name:       "card shares",
+			expression: `cardShared("BOMBER") and cardShares() == 1`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			shares:     [][2]string{{"GAMBLER", "BOMBER"}},
+			wantWon:    true,
+		},
+		{
+			name:       "winner and conditions",
+			expression: `winner == "BLUE" and "SHY" in me.conditions`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			conditions: []models.Condition{models.ConditionShy},
+			wantWon:    true,
+		},
+		{
+			name:       "players in a room",
+			expression: `countInRoom("RED_ROOM") == 2`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			wantWon:    true,
+		},
+		{
+			name:       "evaluation error is a loss",
+			expression: `me.room > 1`,
+			placements: map[string]models.RoomColor{"GAMBLER": models.RedRoom},
+			wantWon:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placements := map[string]models.RoomColor{
+				"PRESIDENT": models.BlueRoom,
+				"BOMBER":    models.RedRoom,
+			}
+			for roleID, roomColor := range tt.placements {
+				placements[roleID] = roomColor
+			}
+			room := newOutcomeTestRoom(placements)
+			addCardShares(room, tt.shares...)
+			gambler := findPlayer(room, "player-GAMBLER")
+			gambler.Role.WinCondition = &models.WinCondition{Expression: tt.expression}
+			gambler.Conditions = tt.conditions
+
+			outcome := ResolveOutcome(room)
+
+			if result := findPlayerOutcome(t, outcome, "GAMBLER"); result.Won != tt.wantWon {
+				t.Errorf("Expected won=%v, got %v (%s)", tt.wantWon, result.Won, result.Reason)
+			}
+		})
+	}
+}
+
+func TestExpressionEnv_CoversSymbols(t *testing.T) {
+	room := newOutcomeTestRoom(map[string]models.RoomColor{"PRESIDENT": models.BlueRoom})
+	ctx := &outcomeContext{players: room.Players, dead: make(map[string]bool)}
+	env := ctx.expressionEnv(findPlayer(room, "player-PRESIDENT"))
+
+	for _, name := range config.WinExpressionSymbols.Vars {
+		if _, ok := env.Vars[name]; !ok {
+			t.Errorf("Variable %q has no value", name)
+		}
+	}
+	for name := range config.WinExpressionSymbols.Funcs {
+		if _, ok := env.Funcs[name]; !ok {
+			t.Errorf("Function %q has no implementation", name)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/store"
 	ws "github.com/kalee/two-rooms-and-a-boom/internal/websocket"
@@ -26,6 +27,12 @@ func NewPlayerService(roomStore *store.RoomStore, hub *ws.Hub) *PlayerService {
 
 // T036: Implement PlayerService.JoinRoom
 func (s *PlayerService) JoinRoom(roomCode string) (*models.Player, error) {
+	return s.JoinRoomWithLocale(roomCode, i18n.DefaultLocale)
+}
+
+// JoinRoomWithLocale adds a player who reads server text in the given locale
+// (see i18n.Negotiate); their anonymous nickname is in that language too.
+func (s *PlayerService) JoinRoomWithLocale(roomCode, locale string) (*models.Player, error) {
 	// Get room
 	room, err := s.roomStore.Get(roomCode)
 	if err != nil {
@@ -38,7 +45,7 @@ func (s *PlayerService) JoinRoom(roomCode string) (*models.Player, error) {
 	}
 
 	// Check if game already started
-	if room.Status == models.RoomStatusInProgress {
+	if room.IsGameActive() {
 		return nil, models.ErrGameAlreadyStarted
 	}
 
@@ -46,7 +53,7 @@ func (s *PlayerService) JoinRoom(roomCode string) (*models.Player, error) {
 	playerID := uuid.New().String()
 
 	// Generate anonymous nickname
-	nickname := s.generateAnonymousNickname(room)
+	nickname := s.generateAnonymousNickname(room, locale)
 
 	// Determine if player is owner (first player)
 	isOwner := len(room.Players) == 0
@@ -59,6 +66,7 @@ func (s *PlayerService) JoinRoom(roomCode string) (*models.Player, error) {
 		RoomCode:    roomCode,
 		IsOwner:     isOwner,
 		ConnectedAt: time.Now(),
+		Locale:      locale,
 	}
 
 	// Add player to room
@@ -85,10 +93,10 @@ func (s *PlayerService) JoinRoom(roomCode string) (*models.Player, error) {
 }
 
 // generateAnonymousNickname generates a sequential anonymous nickname
-func (s *PlayerService) generateAnonymousNickname(room *models.Room) string {
-	// Generate nickname like "플레이어1", "플레이어2", etc.
+func (s *PlayerService) generateAnonymousNickname(room *models.Room, locale string) string {
+	// Generate nickname like "플레이어1", "플레이어2" ("Player1" in English), etc.
 	playerNumber := len(room.Players) + 1
-	return fmt.Sprintf("플레이어%d", playerNumber)
+	return i18n.Msg("player.default_nickname", playerNumber).Render(locale)
 }
 
 // T037: Implement PlayerService.UpdateNickname
@@ -172,7 +180,7 @@ func (s *PlayerService) LeaveRoom(roomCode, playerID string) error {
 	// If the leaving player is the owner, delete the room only if game is not in progress
 	if wasOwner {
 		// Don't delete room during active games - let players continue
-		if room.Status != models.RoomStatusInProgress {
+		if !room.IsGameActive() {
 			if err := s.roomStore.Delete(roomCode); err != nil {
 				fmt.Printf("[WARN] Failed to delete room %s after owner left: %v\n", roomCode, err)
 				return err
@@ -206,7 +214,7 @@ func (s *PlayerService) LeaveRoom(roomCode, playerID string) error {
 	}
 
 	// If game is in progress, keep player in room for potential rejoin
-	if room.Status == models.RoomStatusInProgress {
+	if room.IsGameActive() {
 		fmt.Printf("[INFO] Player %s left room %s during active game - keeping player alive for rejoin\n", playerID, roomCode)
 
 		// Broadcast PLAYER_LEFT event so other players know they disconnected
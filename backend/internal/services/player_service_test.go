@@ -519,7 +519,7 @@ func TestPlayerService_LeaveRoom(t *testing.T) {
 		}
 	})
 
-	t.Run("transfer ownership when owner leaves", func(t *testing.T) {
+	t.Run("delete room when owner leaves lobby", func(t *testing.T) {
 		roomStore := store.NewRoomStore()
 		playerService := NewPlayerService(roomStore, nil)
 
@@ -556,18 +556,9 @@ func TestPlayerService_LeaveRoom(t *testing.T) {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		// Verify player1 is removed
-		updatedRoom, _ := roomStore.Get(room.Code)
-		if len(updatedRoom.Players) != 1 {
-			t.Errorf("Expected 1 player remaining, got %d", len(updatedRoom.Players))
-		}
-
-		// Verify ownership transferred to player2
-		if updatedRoom.Players[0].ID != "player2" {
-			t.Error("Expected player2 to be the remaining player")
-		}
-		if !updatedRoom.Players[0].IsOwner {
-			t.Error("Expected ownership to transfer to player2")
+		// Verify the room is closed when the owner leaves the lobby
+		if _, err := roomStore.Get(room.Code); err != models.ErrRoomNotFound {
+			t.Errorf("Expected room to be deleted, got %v", err)
 		}
 	})
 
@@ -600,10 +591,9 @@ func TestPlayerService_LeaveRoom(t *testing.T) {
 			t.Fatalf("Expected no error, got %v", err)
 		}
 
-		// Verify room is empty
-		updatedRoom, _ := roomStore.Get(room.Code)
-		if len(updatedRoom.Players) != 0 {
-			t.Errorf("Expected 0 players, got %d", len(updatedRoom.Players))
+		// Verify room is deleted
+		if _, err := roomStore.Get(room.Code); err != models.ErrRoomNotFound {
+			t.Errorf("Expected room to be deleted, got %v", err)
 		}
 	})
 
@@ -0,0 +1,263 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
+)
+
+// relationshipPowers maps once-per-game dual-target powers to the condition they give
+var relationshipPowers = map[string]models.Condition{
+	"CUPID": models.ConditionInLove,
+	"ERIS":  models.ConditionInHate,
+}
+
+// forcedSharePowers maps once-per-round powers that force a card share to their target count
+// AGENT forces one player to share with the user; ENFORCER forces two players to share with each other.
+var forcedSharePowers = map[string]int{
+	"AGENT":    1,
+	"ENFORCER": 2,
+}
+
+// PowerService handles activated role powers
+type PowerService struct {
+	store        *store.RoomStore
+	hub          *websocket.Hub
+	shareService *ShareService
+	mu           sync.Mutex
+}
+
+// NewPowerService creates a new PowerService instance
+func NewPowerService(store *store.RoomStore, hub *websocket.Hub) *PowerService {
+	return &PowerService{
+		store: store,
+		hub:   hub,
+	}
+}
+
+// SetShareService sets the ShareService used by forced-share powers
+func (ps *PowerService) SetShareService(ss *ShareService) {
+	ps.shareService = ss
+}
+
+// UsePower activates the player's role power on the given targets
+func (ps *PowerService) UsePower(roomCode, playerID string, targetIDs []string) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	room, err := ps.store.Get(roomCode)
+	if err != nil {
+		return err
+	}
+
+	if room.Status != models.RoomStatusInProgress || room.GameSession == nil {
+		return errors.New("game is not in progress")
+	}
+
+	user := findPlayer(room, playerID)
+	if user == nil {
+		return models.ErrPlayerNotFound
+	}
+
+	if user.Role == nil || user.Role.Power == "" {
+		return errors.New("your role has no power")
+	}
+
+	if condition, ok := relationshipPowers[user.Role.Power]; ok {
+		return ps.useRelationshipPower(room, user, targetIDs, condition)
+	}
+	if targetCount, ok := forcedSharePowers[user.Role.Power]; ok {
+		return ps.useForcedSharePower(room, user, targetIDs, targetCount)
+	}
+	if user.Role.Power == "PSYCHOLOGIST" {
+		return ps.useCurePower(room, user, targetIDs)
+	}
+
+	return fmt.Errorf("power '%s' cannot be used directly", user.Role.Power)
+}
+
+// useRelationshipPower applies a once-per-game paired condition (Cupid, Eris)
+func (ps *PowerService) useRelationshipPower(room *models.Room, user *models.Player, targetIDs []string, condition models.Condition) error {
+	roomCode := room.Code
+	playerID := user.ID
+
+	if room.GameSession.UsedPowers[playerID] {
+		return errors.New("power already used this game")
+	}
+
+	targets, err := validatePowerTargets(room, user, targetIDs, 2)
+	if err != nil {
+		return err
+	}
+
+	// Both targets gain the paired condition; an opposite condition cancels it instead
+	for _, target := range targets {
+		if !target.AddCondition(condition) {
+			log.Printf("[INFO] Condition %s cancelled for player %s", condition, target.ID)
+		}
+	}
+
+	room.GameSession.Relationships = append(room.GameSession.Relationships, &models.Relationship{
+		Condition:    condition,
+		PlayerIDs:    [2]string{targets[0].ID, targets[1].ID},
+		SourceID:     user.ID,
+		SourceRoleID: user.Role.ID,
+		CreatedAt:    time.Now(),
+	})
+
+	if room.GameSession.UsedPowers == nil {
+		room.GameSession.UsedPowers = make(map[string]bool)
+	}
+	room.GameSession.UsedPowers[playerID] = true
+
+	if err := ps.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Power used: room=%s power=%s user=%s targets=%s,%s",
+		roomCode, user.Role.Power, playerID, targets[0].ID, targets[1].ID)
+
+	for _, target := range targets {
+		ps.sendConditionsChanged(roomCode, target, user, i18n.Msg("conditions.power_used", roleDisplayName(user.Role)))
+	}
+
+	return nil
+}
+
+// useForcedSharePower forces a card share that cannot be refused (Agent, Enforcer)
+// Usage is tracked on the round state, so it resets when the next round starts.
+func (ps *PowerService) useForcedSharePower(room *models.Room, user *models.Player, targetIDs []string, targetCount int) error {
+	if ps.shareService == nil {
+		return errors.New("sharing is not available")
+	}
+
+	roundState := room.GameSession.RoundState
+	if roundState == nil {
+		return errors.New("no active round")
+	}
+
+	if roundState.UsedPowers[user.ID] {
+		return errors.New("power already used this round")
+	}
+
+	targets, err := validatePowerTargets(room, user, targetIDs, targetCount)
+	if err != nil {
+		return err
+	}
+
+	// Agent shares with the target; Enforcer makes the two targets share with each other
+	pair := [2]string{user.ID, targets[0].ID}
+	if targetCount == 2 {
+		pair = [2]string{targets[0].ID, targets[1].ID}
+	}
+
+	if _, err := ps.shareService.ForceShare(room.Code, user.ID, pair[0], pair[1]); err != nil {
+		return err
+	}
+
+	if roundState.UsedPowers == nil {
+		roundState.UsedPowers = make(map[string]bool)
+	}
+	roundState.UsedPowers[user.ID] = true
+
+	if err := ps.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Power used: room=%s power=%s user=%s round=%d players=%s,%s",
+		room.Code, user.Role.Power, user.ID, roundState.RoundNumber, pair[0], pair[1])
+
+	return nil
+}
+
+// useCurePower card shares with a player and cures their psych conditions (Psychologist)
+// The share is forced because the conditions being cured could otherwise forbid it.
+func (ps *PowerService) useCurePower(room *models.Room, user *models.Player, targetIDs []string) error {
+	if ps.shareService == nil {
+		return errors.New("sharing is not available")
+	}
+
+	targets, err := validatePowerTargets(room, user, targetIDs, 1)
+	if err != nil {
+		return err
+	}
+	target := targets[0]
+
+	if _, err := ps.shareService.ForceShare(room.Code, user.ID, user.ID, target.ID); err != nil {
+		return err
+	}
+
+	cured := target.CurePsychConditions()
+	if err := ps.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Power used: room=%s power=%s user=%s target=%s cured=%v",
+		room.Code, user.Role.Power, user.ID, target.ID, cured)
+
+	if len(cured) > 0 {
+		ps.sendConditionsChanged(room.Code, target, user, i18n.Msg("conditions.cured", roleDisplayName(user.Role)))
+	}
+
+	return nil
+}
+
+// validatePowerTargets checks that the targets are distinct players in the user's room
+// The user can never target themselves.
+func validatePowerTargets(room *models.Room, user *models.Player, targetIDs []string, count int) ([]*models.Player, error) {
+	if len(targetIDs) != count {
+		return nil, fmt.Errorf("must select exactly %d targets", count)
+	}
+
+	seen := make(map[string]bool)
+	targets := make([]*models.Player, 0, count)
+	for _, targetID := range targetIDs {
+		if targetID == user.ID {
+			return nil, errors.New("cannot use power on yourself")
+		}
+		if seen[targetID] {
+			return nil, errors.New("duplicate player in selection")
+		}
+		seen[targetID] = true
+
+		target := findPlayer(room, targetID)
+		if target == nil {
+			return nil, errors.New("invalid player ID in selection")
+		}
+		if target.CurrentRoom != user.CurrentRoom {
+			return nil, errors.New("can only target players in your room")
+		}
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// sendConditionsChanged privately tells a player their current conditions
+func (ps *PowerService) sendConditionsChanged(roomCode string, target, source *models.Player, reason i18n.Message) {
+	if ps.hub == nil {
+		return
+	}
+
+	payload := &websocket.ConditionsChangedPayload{
+		Conditions: append([]models.Condition{}, target.Conditions...),
+		Source:     &websocket.LeaderInfo{ID: source.ID, Nickname: source.Nickname},
+		Reason:     reason.Render(target.Locale),
+	}
+
+	msg, err := websocket.NewMessage(websocket.MessageConditionsChanged, payload)
+	if err != nil {
+		log.Printf("[ERROR] Failed to create CONDITIONS_CHANGED message: %v", err)
+		return
+	}
+
+	data, _ := msg.Marshal()
+	ps.hub.SendToClient(roomCode, target.ID, data)
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// newPowerTestRoom creates an in-progress room with a Cupid, an Eris and three other players
+// Everyone is in the red room except p5
+func newPowerTestRoom(t *testing.T, roomStore *store.RoomStore) *models.Room {
+	t.Helper()
+	room := &models.Room{
+		Code:   "POWER1",
+		Status: models.RoomStatusInProgress,
+		Players: []*models.Player{
+			{ID: "cupid", Nickname: "큐피드", Team: models.TeamRed, Role: &models.Role{ID: "CUPID", Team: models.TeamRed, Power: "CUPID"}, CurrentRoom: models.RedRoom},
+			{ID: "eris", Nickname: "에리스", Team: models.TeamBlue, Role: &models.Role{ID: "ERIS", Team: models.TeamBlue, Power: "ERIS"}, CurrentRoom: models.RedRoom},
+			{ID: "p3", Nickname: "플레이어3", Team: models.TeamBlue, Role: &models.Role{ID: "PRESIDENT", Team: models.TeamBlue}, CurrentRoom: models.RedRoom},
+			{ID: "p4", Nickname: "플레이어4", Team: models.TeamRed, Role: &models.Role{ID: "BOMBER", Team: models.TeamRed}, CurrentRoom: models.RedRoom},
+			{ID: "p5", Nickname: "플레이어5", Team: models.TeamRed, Role: &models.Role{ID: "RED_TEAM", Team: models.TeamRed}, CurrentRoom: models.BlueRoom},
+		},
+		GameSession: &models.GameSession{CurrentRound: 1},
+	}
+	if err := roomStore.Create(room); err != nil {
+		t.Fatalf("Failed to create room: %v", err)
+	}
+	return room
+}
+
+func TestPowerService_UsePower(t *testing.T) {
+	tests := []struct {
+		name      string
+		userID    string
+		targetIDs []string
+		wantErr   bool
+	}{
+		{name: "cupid targets two players", userID: "cupid", targetIDs: []string{"p3", "p4"}},
+		{name: "eris targets two players", userID: "eris", targetIDs: []string{"p3", "p4"}},
+		{name: "reject self target", userID: "cupid", targetIDs: []string{"cupid", "p4"}, wantErr: true},
+		{name: "reject single target", userID: "cupid", targetIDs: []string{"p3"}, wantErr: true},
+		{name: "reject duplicate target", userID: "cupid", targetIDs: []string{"p3", "p3"}, wantErr: true},
+		{name: "reject target in other room", userID: "cupid", targetIDs: []string{"p3", "p5"}, wantErr: true},
+		{name: "reject role without power", userID: "p3", targetIDs: []string{"p4", "cupid"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newPowerTestRoom(t, roomStore)
+			powerService := NewPowerService(roomStore, nil)
+
+			err := powerService.UsePower(room.Code, tt.userID, tt.targetIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err == nil && len(room.GameSession.Relationships) != 1 {
+				t.Errorf("Expected 1 relationship, got %d", len(room.GameSession.Relationships))
+			}
+		})
+	}
+
+	t.Run("power can only be used once per game", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newPowerTestRoom(t, roomStore)
+		powerService := NewPowerService(roomStore, nil)
+
+		if err := powerService.UsePower(room.Code, "cupid", []string{"p3", "p4"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := powerService.UsePower(room.Code, "cupid", []string{"p3", "eris"}); err == nil {
+			t.Error("Expected error for second use")
+		}
+	})
+
+	t.Run("in love and in hate cancel each other", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newPowerTestRoom(t, roomStore)
+		powerService := NewPowerService(roomStore, nil)
+
+		powerService.UsePower(room.Code, "cupid", []string{"p3", "p4"})
+		powerService.UsePower(room.Code, "eris", []string{"p3", "cupid"})
+
+		president := findPlayer(room, "p3")
+		if president.HasCondition(models.ConditionInLove) || president.HasCondition(models.ConditionInHate) {
+			t.Errorf("Expected conditions to cancel, got %v", president.Conditions)
+		}
+		if !findPlayer(room, "p4").HasCondition(models.ConditionInLove) {
+			t.Error("Expected Bomber to stay in love")
+		}
+		if !findPlayer(room, "cupid").HasCondition(models.ConditionInHate) {
+			t.Error("Expected Cupid to gain in hate")
+		}
+	})
+}
+
+func TestPowerService_ForcedShare(t *testing.T) {
+	newForcedShareRoom := func(t *testing.T, roomStore *store.RoomStore) *models.Room {
+		t.Helper()
+		room := &models.Room{
+			Code:   "FORCE1",
+			Status: models.RoomStatusInProgress,
+			Players: []*models.Player{
+				{ID: "agent", Nickname: "요원", Team: models.TeamBlue, Role: &models.Role{ID: "AGENT", Team: models.TeamBlue, Power: "AGENT"}, CurrentRoom: models.RedRoom},
+				{ID: "enforcer", Nickname: "집행자", Team: models.TeamRed, Role: &models.Role{ID: "ENFORCER", Team: models.TeamRed, Power: "ENFORCER"}, CurrentRoom: models.RedRoom},
+				{ID: "p3", Nickname: "플레이어3", Team: models.TeamBlue, Role: &models.Role{ID: "BLUE_TEAM", Team: models.TeamBlue}, CurrentRoom: models.RedRoom},
+				{ID: "p4", Nickname: "플레이어4", Team: models.TeamRed, Role: &models.Role{ID: "RED_TEAM", Team: models.TeamRed}, CurrentRoom: models.RedRoom},
+				{ID: "p5", Nickname: "플레이어5", Team: models.TeamRed, Role: &models.Role{ID: "RED_TEAM", Team: models.TeamRed}, CurrentRoom: models.BlueRoom},
+			},
+			GameSession: &models.GameSession{
+				CurrentRound: 1,
+				RoundState:   &models.RoundState{RoundNumber: 1, Status: models.RoundStatusActive},
+			},
+		}
+		if err := roomStore.Create(room); err != nil {
+			t.Fatalf("Failed to create room: %v", err)
+		}
+		return room
+	}
+
+	tests := []struct {
+		name      string
+		userID    string
+		targetIDs []string
+		wantPair  [2]string
+		wantErr   bool
+	}{
+		{name: "agent shares with target", userID: "agent", targetIDs: []string{"p3"}, wantPair: [2]string{"agent", "p3"}},
+		{name: "enforcer makes targets share", userID: "enforcer", targetIDs: []string{"p3", "p4"}, wantPair: [2]string{"p3", "p4"}},
+		{name: "reject agent self target", userID: "agent", targetIDs: []string{"agent"}, wantErr: true},
+		{name: "reject agent with two targets", userID: "agent", targetIDs: []string{"p3", "p4"}, wantErr: true},
+		{name: "reject enforcer including self", userID: "enforcer", targetIDs: []string{"enforcer", "p4"}, wantErr: true},
+		{name: "reject target in other room", userID: "enforcer", targetIDs: []string{"p3", "p5"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newForcedShareRoom(t, roomStore)
+			powerService := NewPowerService(roomStore, nil)
+			powerService.SetShareService(NewShareService(roomStore, nil))
+
+			err := powerService.UsePower(room.Code, tt.userID, tt.targetIDs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			completed := room.GameSession.CompletedShares()
+			if len(completed) != 1 {
+				t.Fatalf("Expected 1 completed share, got %d", len(completed))
+			}
+			share := completed[0]
+			if share.Type != models.ShareTypeCard || share.ForcedBy != tt.userID {
+				t.Errorf("Expected forced card share by %s, got type=%s forcedBy=%s", tt.userID, share.Type, share.ForcedBy)
+			}
+			if !share.Involves(tt.wantPair[0]) || !share.Involves(tt.wantPair[1]) {
+				t.Errorf("Expected share between %v, got %s and %s", tt.wantPair, share.InitiatorID, share.TargetID)
+			}
+		})
+	}
+
+	t.Run("once per round and reset by a new round", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newForcedShareRoom(t, roomStore)
+		powerService := NewPowerService(roomStore, nil)
+		powerService.SetShareService(NewShareService(roomStore, nil))
+
+		if err := powerService.UsePower(room.Code, "agent", []string{"p3"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := powerService.UsePower(room.Code, "agent", []string{"p4"}); err == nil {
+			t.Error("Expected error for second use in the same round")
+		}
+
+		// StartRound replaces the round state, which clears per-round usage
+		room.GameSession.CurrentRound = 2
+		room.GameSession.RoundState = &models.RoundState{RoundNumber: 2, Status: models.RoundStatusActive}
+
+		if err := powerService.UsePower(room.Code, "agent", []string{"p4"}); err != nil {
+			t.Errorf("Expected power to be usable in the next round, got %v", err)
+		}
+	})
+
+	t.Run("reject without active round", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newForcedShareRoom(t, roomStore)
+		room.GameSession.RoundState = nil
+		powerService := NewPowerService(roomStore, nil)
+		powerService.SetShareService(NewShareService(roomStore, nil))
+
+		if err := powerService.UsePower(room.Code, "agent", []string{"p3"}); err == nil {
+			t.Error("Expected error without an active round")
+		}
+	})
+}
+
+func TestPowerService_Psychologist(t *testing.T) {
+	roomStore := store.NewRoomStore()
+	room := &models.Room{
+		Code:   "PSYCH1",
+		Status: models.RoomStatusInProgress,
+		Players: []*models.Player{
+			{ID: "psych", Nickname: "심리학자", Team: models.TeamBlue, Role: &models.Role{ID: "BLUE_PSYCHOLOGIST", Team: models.TeamBlue, Power: "PSYCHOLOGIST"}, CurrentRoom: models.RedRoom},
+			{ID: "shy", Nickname: "수줍은", Team: models.TeamRed, Role: &models.Role{ID: "RED_SHY_GUY", Team: models.TeamRed}, CurrentRoom: models.RedRoom,
+				Conditions: []models.Condition{models.ConditionShy, models.ConditionInLove}},
+		},
+		GameSession: &models.GameSession{CurrentRound: 1},
+	}
+	if err := roomStore.Create(room); err != nil {
+		t.Fatalf("Failed to create room: %v", err)
+	}
+	powerService := NewPowerService(roomStore, nil)
+	powerService.SetShareService(NewShareService(roomStore, nil))
+
+	if err := powerService.UsePower(room.Code, "psych", []string{"shy"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	patient := findPlayer(room, "shy")
+	if patient.HasCondition(models.ConditionShy) {
+		t.Error("Expected shy condition to be cured")
+	}
+	if !patient.HasCondition(models.ConditionInLove) {
+		t.Error("Expected non-psych conditions to be kept")
+	}
+	if len(room.GameSession.CompletedShares()) != 1 {
+		t.Errorf("Expected the cure to record a card share, got %d", len(room.GameSession.CompletedShares()))
+	}
+}
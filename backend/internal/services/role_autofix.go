@@ -0,0 +1,244 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// Codes reported by RoleAssignmentError
+const (
+	RoleErrConfigNotFound      = "ROLE_CONFIG_NOT_FOUND"
+	RoleErrInvalidDistribution = "INVALID_ROLE_DISTRIBUTION"
+)
+
+// RoleAssignmentError reports why a game's roles could not be dealt
+// Roles are never replaced with built-in ones; the host changes the selection or
+// starts again with auto-fix.
+type RoleAssignmentError struct {
+	Code   string                    // RoleErrConfigNotFound or RoleErrInvalidDistribution
+	Errors []string                  // Every problem found
+	Fix    []*models.RoleCountChange // Changes auto-fix would make, if they resolve every problem
+}
+
+func (e *RoleAssignmentError) Error() string {
+	return "role assignment failed: " + strings.Join(e.Errors, "; ")
+}
+
+// AutoFixRoleSelection adjusts a role selection until its roles can be dealt to playerCount players
+// Without a selection it starts from the roles the configuration deals by default.
+// Roles that cannot be dealt are removed, a missing team leader is added, surplus cards
+// are trimmed from the least important roles (highest priority number) first and, without a
+// selection, Red operatives are added if Blue would otherwise take more cards than Red.
+// The selection is returned unchanged, with no changes, if it can already be dealt.
+func AutoFixRoleSelection(roleConfig *config.RoleConfig, playerCount int, selectedRoles map[string]int) (map[string]int, []*models.RoleCountChange) {
+	if PlanRoleDistribution(roleConfig, playerCount, selectedRoles).Err() == nil {
+		return selectedRoles, nil
+	}
+
+	original := defaultSelection(roleConfig, playerCount, selectedRoles)
+	fixed := make(map[string]int, len(original))
+	for roleID, count := range original {
+		if count > 0 {
+			fixed[roleID] = count
+		}
+	}
+	reasons := make(map[string]string)
+
+	// Unknown roles and roles needing more players cannot be dealt
+	for roleID := range fixed {
+		roleDef := roleConfig.FindRole(roleID)
+		if roleDef == nil {
+			delete(fixed, roleID)
+			reasons[roleID] = "unknown role"
+		} else if roleDef.MinPlayers > playerCount {
+			delete(fixed, roleID)
+			reasons[roleID] = fmt.Sprintf("needs at least %d players", roleDef.MinPlayers)
+		}
+	}
+	dropUnpaired(roleConfig, fixed, reasons)
+
+	// Both teams need a leader
+	for _, teamColor := range []config.TeamColor{config.TeamRed, config.TeamBlue} {
+		if selectedLeader(roleConfig, teamColor, fixed) {
+			continue
+		}
+		if leader := availableLeader(roleConfig, teamColor, playerCount); leader != nil {
+			fixed[leader.ID] = 1
+			reasons[leader.ID] = fmt.Sprintf("%s team needs a leader", teamColor)
+		}
+	}
+
+	// Trim one card at a time until the roles fit; burying may change with the roles dealt
+	for {
+		plan := PlanRoleDistribution(roleConfig, playerCount, fixed)
+		total := 0
+		for _, count := range fixed {
+			total += count
+		}
+		if total <= plan.CardCount {
+			break
+		}
+		roleID := leastImportantRole(roleConfig, fixed)
+		if roleID == "" {
+			break
+		}
+		fixed[roleID]--
+		if fixed[roleID] == 0 {
+			delete(fixed, roleID)
+		}
+		reasons[roleID] = fmt.Sprintf("too many roles for %d cards", plan.CardCount)
+		dropUnpaired(roleConfig, fixed, reasons)
+	}
+
+	// Without a selection operatives fill both teams: Blue takes the cards left over, so Red
+	// operatives even the teams out. A host's selection is otherwise kept as chosen.
+	if plan := PlanRoleDistribution(roleConfig, playerCount, fixed); plan.Err() == nil && len(selectedRoles) == 0 {
+		red, blue := plan.Teams[0], plan.Teams[1]
+		added := min((blue.Size-red.Size)/2, blue.Fill) // Only cards Blue fills can move to Red
+		if operative := availableOperative(roleConfig, config.TeamRed, playerCount); operative != nil && added > 0 {
+			fixed[operative.ID] += added
+			reasons[operative.ID] = "evens out the RED and BLUE teams"
+		}
+	}
+
+	return fixed, selectionChanges(roleConfig, original, fixed, reasons)
+}
+
+// defaultSelection returns the selection, or the roles and counts dealt without one
+// Operative roles are left out: they fill whatever seats the selection leaves.
+func defaultSelection(roleConfig *config.RoleConfig, playerCount int, selectedRoles map[string]int) map[string]int {
+	if len(selectedRoles) > 0 {
+		return selectedRoles
+	}
+	selection := make(map[string]int)
+	for _, roleDef := range roleConfig.Roles {
+		if roleDef.MinPlayers > playerCount || isFillRole(roleDef) {
+			continue
+		}
+		if count := roleDef.Count.GetCount(playerCount); count > 0 {
+			selection[roleDef.ID] = count
+		}
+	}
+	return selection
+}
+
+// dropUnpaired removes roles whose required partner is no longer selected, until none are left
+func dropUnpaired(roleConfig *config.RoleConfig, selection map[string]int, reasons map[string]string) {
+	for changed := true; changed; {
+		changed = false
+		for roleID := range selection {
+			for _, requiredID := range roleConfig.FindRole(roleID).RequiresRoles {
+				if selection[requiredID] <= 0 {
+					delete(selection, roleID)
+					reasons[roleID] = fmt.Sprintf("requires '%s'", requiredID)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+}
+
+// selectedLeader reports whether the selection deals a leader to the team
+func selectedLeader(roleConfig *config.RoleConfig, teamColor config.TeamColor, selection map[string]int) bool {
+	for roleID := range selection {
+		roleDef := roleConfig.FindRole(roleID)
+		if roleDef.Team == teamColor && roleDef.Type == config.RoleTypeLeader {
+			return true
+		}
+	}
+	return false
+}
+
+// availableLeader returns the team's first leader role (by priority) that can be dealt on its own
+func availableLeader(roleConfig *config.RoleConfig, teamColor config.TeamColor, playerCount int) *config.RoleDefinition {
+	var leader *config.RoleDefinition
+	for i := range roleConfig.Roles {
+		roleDef := &roleConfig.Roles[i]
+		if roleDef.Team != teamColor || roleDef.Type != config.RoleTypeLeader {
+			continue
+		}
+		if roleDef.MinPlayers > playerCount || len(roleDef.RequiresRoles) > 0 {
+			continue
+		}
+		if leader == nil || roleDef.Priority < leader.Priority {
+			leader = roleDef
+		}
+	}
+	return leader
+}
+
+// availableOperative returns the team's operative role (priority 99) if it can be dealt on its own
+func availableOperative(roleConfig *config.RoleConfig, teamColor config.TeamColor, playerCount int) *config.RoleDefinition {
+	for i := range roleConfig.Roles {
+		roleDef := &roleConfig.Roles[i]
+		if roleDef.Team == teamColor && isFillRole(*roleDef) &&
+			roleDef.MinPlayers <= playerCount && len(roleDef.RequiresRoles) == 0 {
+			return roleDef
+		}
+	}
+	return nil
+}
+
+// leastImportantRole returns the selected non-leader role with the highest priority number
+// On a tie the role of the team with the most cards is chosen, so trimming operatives keeps
+// Red and Blue even, then the last one in configuration order.
+func leastImportantRole(roleConfig *config.RoleConfig, selection map[string]int) string {
+	teamCards := make(map[config.TeamColor]int)
+	for roleID, count := range selection {
+		if roleDef := roleConfig.FindRole(roleID); roleDef != nil {
+			teamCards[roleDef.Team] += count
+		}
+	}
+
+	var least *config.RoleDefinition
+	for i := range roleConfig.Roles {
+		roleDef := &roleConfig.Roles[i]
+		if selection[roleDef.ID] <= 0 || roleDef.Type == config.RoleTypeLeader {
+			continue
+		}
+		if least == nil || roleDef.Priority > least.Priority ||
+			(roleDef.Priority == least.Priority && teamCards[roleDef.Team] >= teamCards[least.Team]) {
+			least = roleDef
+		}
+	}
+	if least == nil {
+		return ""
+	}
+	return least.ID
+}
+
+// selectionChanges lists the differences between two selections, in configuration order
+// followed by unknown roles
+func selectionChanges(roleConfig *config.RoleConfig, before, after map[string]int, reasons map[string]string) []*models.RoleCountChange {
+	roleIDs := make([]string, 0, len(roleConfig.Roles))
+	for _, roleDef := range roleConfig.Roles {
+		roleIDs = append(roleIDs, roleDef.ID)
+	}
+	var unknown []string
+	for roleID := range before {
+		if roleConfig.FindRole(roleID) == nil {
+			unknown = append(unknown, roleID)
+		}
+	}
+	sort.Strings(unknown)
+
+	var changes []*models.RoleCountChange
+	for _, roleID := range append(roleIDs, unknown...) {
+		from, to := max(before[roleID], 0), after[roleID]
+		if from == to {
+			continue
+		}
+		changes = append(changes, &models.RoleCountChange{
+			RoleID: roleID,
+			From:   from,
+			To:     to,
+			Reason: reasons[roleID],
+		})
+	}
+	return changes
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// formatChanges renders changes as "ROLE:from->to" entries
+func formatChanges(changes []*models.RoleCountChange) string {
+	var parts []string
+	for _, change := range changes {
+		parts = append(parts, fmt.Sprintf("%s:%d->%d", change.RoleID, change.From, change.To))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestAutoFixRoleSelection(t *testing.T) {
+	tests := []struct {
+		name          string
+		playerCount   int
+		selectedRoles map[string]int
+		wantChanges   string
+	}{
+		{
+			name:          "valid selection is left alone",
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_SPY": 1},
+		},
+		{
+			name:          "missing leader is added",
+			playerCount:   8,
+			selectedRoles: map[string]int{"BOMBER": 1, "RED_TEAM": 2},
+			wantChanges:   "PRESIDENT:0->1",
+		},
+		{
+			name:          "role needing more players is removed",
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "GAMBLER": 1},
+			wantChanges:   "GAMBLER:1->0",
+		},
+		{
+			name:          "unknown role is removed",
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "SPY": 2},
+			wantChanges:   "SPY:2->0",
+		},
+		{
+			name:          "surplus is trimmed from the least important role",
+			playerCount:   6,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_SPY": 1, "RED_TEAM": 4},
+			wantChanges:   "RED_TEAM:4->3",
+		},
+		{
+			name:        "without a selection operatives fill the teams",
+			playerCount: 8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleConfig := newDistributionTestConfig(t, config.BuryNever)
+
+			fixed, changes := AutoFixRoleSelection(roleConfig, tt.playerCount, tt.selectedRoles)
+			if got := formatChanges(changes); got != tt.wantChanges {
+				t.Errorf("Expected changes %q, got %q", tt.wantChanges, got)
+			}
+			for _, change := range changes {
+				if change.Reason == "" {
+					t.Errorf("Expected a reason for the change to %s", change.RoleID)
+				}
+			}
+			if err := PlanRoleDistribution(roleConfig, tt.playerCount, fixed).Err(); err != nil {
+				t.Errorf("Expected the fixed selection to be dealt, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGameService_StartGame_RoleAssignmentErrors(t *testing.T) {
+	newRoom := func(t *testing.T, roleConfigID string) (*GameService, *models.Room) {
+		t.Helper()
+		roomStore := store.NewRoomStore()
+		gameService := NewGameService(roomStore, newBuryTestService(t, config.BuryNever).roleLoader)
+		room := &models.Room{
+			Code:          "FIX001",
+			Status:        models.RoomStatusWaiting,
+			Players:       newBuryTestPlayers(8),
+			RoleConfigID:  roleConfigID,
+			SelectedRoles: map[string]int{"BOMBER": 1, "RED_TEAM": 2},
+		}
+		roomStore.Create(room)
+		return gameService, room
+	}
+
+	t.Run("fails without changing the room", func(t *testing.T) {
+		gameService, room := newRoom(t, "bury-test")
+
+		_, err := gameService.StartGame(room.Code)
+		var assignErr *RoleAssignmentError
+		if !errors.As(err, &assignErr) {
+			t.Fatalf("Expected RoleAssignmentError, got %v", err)
+		}
+		if assignErr.Code != RoleErrInvalidDistribution || len(assignErr.Errors) == 0 {
+			t.Errorf("Expected %s with errors, got %s %v", RoleErrInvalidDistribution, assignErr.Code, assignErr.Errors)
+		}
+		if got := formatChanges(assignErr.Fix); got != "PRESIDENT:0->1" {
+			t.Errorf("Expected the fix to add a President, got %q", got)
+		}
+		if room.Status != models.RoomStatusWaiting || room.GameSession != nil {
+			t.Errorf("Expected the room to stay in the lobby, got status %s", room.Status)
+		}
+		for _, player := range room.Players {
+			if player.Role != nil || player.Team != "" {
+				t.Errorf("Expected player %s to have no role or team, got %v %s", player.ID, player.Role, player.Team)
+			}
+		}
+	})
+
+	t.Run("auto-fix deals the adjusted roles and records the changes", func(t *testing.T) {
+		gameService, room := newRoom(t, "bury-test")
+
+		session, err := gameService.StartGameWithOptions(room.Code, StartGameOptions{AutoFix: true})
+		if err != nil {
+			t.Fatalf("StartGameWithOptions failed: %v", err)
+		}
+		if got := formatChanges(session.RoleAdjustments); got != "PRESIDENT:0->1" {
+			t.Errorf("Expected the President adjustment on the session, got %q", got)
+		}
+		presidents := 0
+		for _, player := range room.Players {
+			if player.Role != nil && player.Role.ID == "PRESIDENT" {
+				presidents++
+			}
+		}
+		if presidents != 1 {
+			t.Errorf("Expected 1 President to be dealt, got %d", presidents)
+		}
+	})
+
+	t.Run("missing configuration is reported", func(t *testing.T) {
+		gameService, room := newRoom(t, "missing")
+
+		_, err := gameService.StartGameWithOptions(room.Code, StartGameOptions{AutoFix: true})
+		var assignErr *RoleAssignmentError
+		if !errors.As(err, &assignErr) || assignErr.Code != RoleErrConfigNotFound {
+			t.Errorf("Expected %s, got %v", RoleErrConfigNotFound, err)
+		}
+	})
+}
+
+func TestGameService_StartGame_ShippedDefault(t *testing.T) {
+	loader := loadShippedRoleConfigs(t)
+
+	for _, playerCount := range []int{6, 8, 11, 30} {
+		t.Run(fmt.Sprintf("%d players", playerCount), func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			gameService := NewGameService(roomStore, loader)
+			room := &models.Room{
+				Code:    "DEF001",
+				Status:  models.RoomStatusWaiting,
+				Players: newBuryTestPlayers(playerCount),
+			}
+			roomStore.Create(room)
+
+			// A room with no configuration or selection starts without auto-fix
+			session, err := gameService.StartGame(room.Code)
+			if err != nil {
+				t.Fatalf("Expected the default roles to be dealt, got %v", err)
+			}
+			if len(session.RoleAdjustments) != 0 {
+				t.Errorf("Expected no role adjustments, got %q", formatChanges(session.RoleAdjustments))
+			}
+			dealt := make(map[string]int)
+			for _, player := range room.Players {
+				if player.Role == nil {
+					t.Fatalf("Player %s has no role assigned", player.ID)
+				}
+				dealt[player.Role.ID]++
+			}
+			if dealt["PRESIDENT"] != 1 || dealt["BOMBER"] != 1 {
+				t.Errorf("Expected one President and one Bomber, got %v", dealt)
+			}
+		})
+	}
+}
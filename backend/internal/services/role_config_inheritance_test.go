@@ -0,0 +1,214 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// extendingTestConfig extends the bury test configuration: it drops the Drunk, raises the
+// President's minPlayers and adds a Gambler
+const extendingTestConfig = `{
+  "id": "house-rules",
+  "name": "House Rules",
+  "version": "1.0.0",
+  "extends": "bury-test",
+  "exclude": ["DRUNK"],
+  "overrides": [{"id": "PRESIDENT", "minPlayers": 7}],
+  "roles": [
+    {"id": "GAMBLER", "name": "Gambler", "team": "GREY", "type": "grey", "count": 1, "minPlayers": 10, "priority": 3, "finalAction": "PREDICT"}
+  ]
+}`
+
+// newInheritanceTestLoader loads the bury test configuration plus the given extra files
+func newInheritanceTestLoader(t *testing.T, files map[string]string) (*config.RoleConfigLoader, error) {
+	t.Helper()
+	dir := t.TempDir()
+	files["bury-test.json"] = fmt.Sprintf(buryTestConfig, config.BuryOdd)
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	loader := config.NewRoleConfigLoader(dir)
+	return loader, loader.LoadAll()
+}
+
+// roleIDs lists a configuration's role IDs in order
+func roleIDs(roleConfig *config.RoleConfig) string {
+	var ids []string
+	for _, role := range roleConfig.Roles {
+		ids = append(ids, role.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestRoleConfigLoader_Extends(t *testing.T) {
+	loader, err := newInheritanceTestLoader(t, map[string]string{"house-rules.json": extendingTestConfig})
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+
+	resolved, err := loader.Get("house-rules")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got, want := roleIDs(resolved), "PRESIDENT BLUE_TEAM BOMBER RED_TEAM GAMBLER"; got != want {
+		t.Errorf("Expected roles %q, got %q", want, got)
+	}
+	if resolved.Bury != config.BuryOdd {
+		t.Errorf("Expected bury to be inherited, got %q", resolved.Bury)
+	}
+	president := resolved.FindRole("PRESIDENT")
+	if president.MinPlayers != 7 || president.Name != "President" || president.Count.GetCount(10) != 1 {
+		t.Errorf("Expected the override to change only minPlayers, got %+v", president)
+	}
+
+	base, _ := loader.Get("bury-test")
+	if base.FindRole("PRESIDENT").MinPlayers != 6 {
+		t.Error("Expected the override to leave the base configuration unchanged")
+	}
+
+	// Changing the base gives the extending configuration a new revision with the change
+	updated := *base
+	updated.Bury = config.BuryNever
+	if err := loader.Update("bury-test", &updated); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	latest, _ := loader.Get("house-rules")
+	if latest.Revision != resolved.Revision+1 || latest.Bury != config.BuryNever {
+		t.Errorf("Expected revision %d with bury=never, got revision %d bury=%s", resolved.Revision+1, latest.Revision, latest.Bury)
+	}
+	if earlier, err := loader.GetRevision("house-rules", resolved.Revision); err != nil || earlier.Bury != config.BuryOdd {
+		t.Errorf("Expected the earlier revision to be kept, got %v", err)
+	}
+
+	// A base that would break the extending configuration is refused
+	broken := *base
+	broken.Roles = broken.Roles[:4] // Drops the Drunk the extending configuration excludes
+	if err := loader.Update("bury-test", &broken); !errors.Is(err, config.ErrInvalidConfig) || !strings.Contains(err.Error(), "house-rules") {
+		t.Errorf("Expected an error naming house-rules, got %v", err)
+	}
+
+	if err := loader.Delete("bury-test"); !errors.Is(err, config.ErrConfigExtended) {
+		t.Errorf("Expected ErrConfigExtended, got %v", err)
+	}
+}
+
+func TestRoleConfigLoader_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "b"}`,
+				"b.json": `{"id": "b", "name": "B", "version": "1", "extends": "a"}`,
+			},
+			wantErr: "extends cycle: a -> b -> a",
+		},
+		{
+			name:    "self",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "a"}`},
+			wantErr: "extends cycle: a -> a",
+		},
+		{
+			name:    "missing base",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "nope"}`},
+			wantErr: "cannot extend 'nope'",
+		},
+		{
+			name:    "unknown excluded role",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "bury-test", "exclude": ["SPY"]}`},
+			wantErr: "exclude: role 'SPY' is not defined in 'bury-test'",
+		},
+		{
+			name:    "override without a base role",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "bury-test", "overrides": [{"id": "SPY", "count": 2}]}`},
+			wantErr: "role 'SPY' is not in the extended roles",
+		},
+		{
+			name:    "include drops a leader",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "extends": "bury-test", "include": ["PRESIDENT", "BLUE_TEAM"]}`},
+			wantErr: "validation failed",
+		},
+		{
+			name:    "exclude without extends",
+			files:   map[string]string{"a.json": `{"id": "a", "name": "A", "version": "1", "exclude": ["DRUNK"], "roles": []}`},
+			wantErr: "need extends",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newInheritanceTestLoader(t, tt.files)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRoomService_CreateCustomRoom_Extends(t *testing.T) {
+	loader, err := newInheritanceTestLoader(t, map[string]string{})
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	roomService := NewRoomService(store.NewRoomStore())
+	roomService.SetRoleLoader(loader)
+
+	roleSet := &config.RoleConfig{Extends: "bury-test", Exclude: []string{"DRUNK"}}
+	room, err := roomService.CreateCustomRoom(10, true, roleSet, nil)
+	if err != nil {
+		t.Fatalf("CreateCustomRoom failed: %v", err)
+	}
+	roleConfig, err := customRoleSet(room)
+	if err != nil {
+		t.Fatalf("Expected a readable inline role set, got %v", err)
+	}
+	if got, want := roleIDs(roleConfig), "PRESIDENT BLUE_TEAM BOMBER RED_TEAM"; got != want {
+		t.Errorf("Expected the resolved role set %q, got %q", want, got)
+	}
+}
+
+func TestRoleConfigLoader_ShippedConfigsDeal(t *testing.T) {
+	loader := loadShippedRoleConfigs(t)
+
+	for _, configID := range []string{"standard", "all-roles"} {
+		t.Run(configID, func(t *testing.T) {
+			roleConfig, err := loader.Get(configID)
+			if err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+
+			// The resolved configuration's smallest game, from the roles it inherits or defines
+			minPlayers := 0
+			for _, roleDef := range roleConfig.Roles {
+				if minPlayers == 0 || roleDef.MinPlayers < minPlayers {
+					minPlayers = roleDef.MinPlayers
+				}
+			}
+
+			for players := minPlayers; players <= 30; players++ {
+				plan := PlanRoleDistribution(roleConfig, players, nil)
+				if err := plan.Err(); err != nil {
+					t.Fatalf("Expected %s to deal %d players, got %v", configID, players, err)
+				}
+				// The inherited operative roles fill the seats left over
+				for _, team := range plan.Teams[:2] {
+					if want := string(team.Team) + "_TEAM"; team.Fill > 0 && team.FillRoleID != want {
+						t.Errorf("Expected %s to be filled with %s, got %s", team.Team, want, team.FillRoleID)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+func TestRoomKeepsRoleConfigRevision(t *testing.T) {
+	gameService := newBuryTestService(t, config.BuryNever)
+	loader := gameService.roleLoader
+	roomService := NewRoomService(store.NewRoomStore())
+	roomService.SetRoleLoader(loader)
+
+	room, err := roomService.CreateRoom(10, true, "bury-test", nil)
+	if err != nil {
+		t.Fatalf("CreateRoom failed: %v", err)
+	}
+	if room.RoleConfigRevision != 1 {
+		t.Fatalf("Expected revision 1, got %d", room.RoleConfigRevision)
+	}
+
+	// Edit the configuration after the room was created
+	current, _ := loader.Get("bury-test")
+	updated := *current
+	updated.Bury = config.BuryAlways
+	if err := loader.Update("bury-test", &updated); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if updated.Revision != 2 {
+		t.Errorf("Expected revision 2 after update, got %d", updated.Revision)
+	}
+
+	selected := map[string]int{"PRESIDENT": 1, "BOMBER": 1}
+	roleConfig, err := gameService.roomRoleConfig(room)
+	if err != nil {
+		t.Fatalf("Failed to get the room's revision: %v", err)
+	}
+	buried, err := gameService.assignRolesFromConfig(newBuryTestPlayers(8), roleConfig, selected)
+	if err != nil {
+		t.Fatalf("Assignment with the room's revision failed: %v", err)
+	}
+	if buried != nil {
+		t.Error("Expected the room's revision to keep bury=never")
+	}
+
+	buried, err = gameService.AssignRolesWithConfig(newBuryTestPlayers(8), "bury-test", selected)
+	if err != nil {
+		t.Fatalf("Assignment with the latest revision failed: %v", err)
+	}
+	if buried == nil {
+		t.Error("Expected the latest revision to bury a card")
+	}
+
+	// The room's revision outlives deletion of the configuration
+	if err := loader.Delete("bury-test"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := loader.Get("bury-test"); err == nil {
+		t.Error("Expected deleted configuration to be gone")
+	}
+	if _, err := gameService.roomRoleConfig(room); err != nil {
+		t.Errorf("Expected the room's revision after deletion, got %v", err)
+	}
+}
+
+func TestRoleConfigLoader_PersistsChanges(t *testing.T) {
+	loader := newBuryTestService(t, config.BuryNever).roleLoader
+
+	current, _ := loader.Get("bury-test")
+	created := *current
+	created.ID = "house-rules"
+	if err := loader.Create(&created); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := loader.Create(&created); err == nil {
+		t.Error("Expected creating an existing configuration to fail")
+	}
+
+	invalid := *current
+	invalid.Roles = nil
+	if err := loader.Update("bury-test", &invalid); err == nil {
+		t.Error("Expected an invalid configuration to be rejected")
+	}
+
+	unsafe := *current
+	unsafe.ID = "../escape"
+	if err := loader.Create(&unsafe); err == nil {
+		t.Error("Expected an ID that is not a file name to be rejected")
+	}
+
+	// A fresh loader over the same directory sees the new file
+	reloaded := config.NewRoleConfigLoader(loader.Dir())
+	if err := reloaded.LoadAll(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if _, err := reloaded.Get("house-rules"); err != nil {
+		t.Errorf("Expected created configuration on disk: %v", err)
+	}
+}
+
+func TestRoleConfigLoader_Reload(t *testing.T) {
+	loader := newBuryTestService(t, config.BuryNever).roleLoader
+	file := filepath.Join(loader.Dir(), "bury-test.json")
+
+	// Reloading unchanged files keeps the revision
+	if err := loader.LoadAll(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if current, _ := loader.Get("bury-test"); current.Revision != 1 {
+		t.Errorf("Expected unchanged revision 1, got %d", current.Revision)
+	}
+
+	// An invalid file anywhere keeps the whole current set
+	if err := os.WriteFile(filepath.Join(loader.Dir(), "broken.json"), []byte(`{"id": "broken"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := os.WriteFile(file, []byte(fmt.Sprintf(buryTestConfig, config.BuryAlways)), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := loader.LoadAll(); err == nil {
+		t.Fatal("Expected reload with an invalid file to fail")
+	}
+	if current, _ := loader.Get("bury-test"); current.Bury != config.BuryNever {
+		t.Errorf("Expected the old set to stay in use, got bury=%s", current.Bury)
+	}
+
+	// Once every file is valid the new set is swapped in as a new revision
+	if err := os.Remove(filepath.Join(loader.Dir(), "broken.json")); err != nil {
+		t.Fatalf("Failed to remove config: %v", err)
+	}
+	if err := loader.LoadAll(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	current, _ := loader.Get("bury-test")
+	if current.Bury != config.BuryAlways || current.Revision != 2 {
+		t.Errorf("Expected bury=always at revision 2, got bury=%s revision=%d", current.Bury, current.Revision)
+	}
+	if pinned, err := loader.GetRevision("bury-test", 1); err != nil || pinned.Bury != config.BuryNever {
+		t.Errorf("Expected revision 1 to stay available, got %v", err)
+	}
+}
+
+func TestRoleConfigLoader_ReloadDuringSaves(t *testing.T) {
+	loader := newBuryTestService(t, config.BuryNever).roleLoader
+	current, _ := loader.Get("bury-test")
+
+	// Reloads running alongside saves must never put back a snapshot older than a save
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				loader.LoadAll()
+			}
+		}
+	}()
+
+	for i := 0; i < 20; i++ {
+		created := *current
+		created.ID = fmt.Sprintf("house-rules-%d", i)
+		if err := loader.Create(&created); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	for i := 0; i < 20; i++ {
+		if _, err := loader.Get(fmt.Sprintf("house-rules-%d", i)); err != nil {
+			t.Errorf("Expected saved configuration after concurrent reloads: %v", err)
+		}
+	}
+}
+
+func TestRoleConfigLoader_Watch(t *testing.T) {
+	loader := newBuryTestService(t, config.BuryNever).roleLoader
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan error, 1)
+	go loader.Watch(ctx, 10*time.Millisecond, func(err error) { reloaded <- err })
+
+	// Make sure the modification time differs from the original write
+	time.Sleep(20 * time.Millisecond)
+	data := fmt.Sprintf(buryTestConfig, config.BuryAlways)
+	if err := os.WriteFile(filepath.Join(loader.Dir(), "bury-test.json"), []byte(data), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	select {
+	case err := <-reloaded:
+		if err != nil {
+			t.Fatalf("Reload failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a reload after the file changed")
+	}
+	if current, _ := loader.Get("bury-test"); current.Bury != config.BuryAlways {
+		t.Errorf("Expected bury=always after reload, got %s", current.Bury)
+	}
+}
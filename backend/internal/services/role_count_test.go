@@ -0,0 +1,29 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+)
+
+func TestPlanRoleDistribution_CountFormula(t *testing.T) {
+	data := strings.Replace(fmt.Sprintf(buryTestConfig, config.BuryNever), `"type": "grey", "count": 1`, `"type": "grey", "count": {"formula": "players/5", "max": 3}`, 1)
+	data = strings.ReplaceAll(data, `"count": 99`, `"count": 0`) // Operatives fill the remaining cards
+	loader, _, err := loadFormatTestConfig(t, "bury-test.json", data)
+	if err != nil {
+		t.Fatalf("LoadAll failed: %v", err)
+	}
+	roleConfig, _ := loader.Get("bury-test")
+
+	for players, want := range map[int]int{8: 1, 12: 2, 24: 3} {
+		plan := PlanRoleDistribution(roleConfig, players, nil)
+		if err := plan.Err(); err != nil {
+			t.Fatalf("Expected %d players to be dealt, got %v", players, err)
+		}
+		if grey := plan.Teams[2]; grey.Size != want {
+			t.Errorf("Expected %d Drunk cards for %d players, got %d", want, players, grey.Size)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// RoleDistribution is the deterministic part of dealing roles: how many of each card every
+// team receives for a player count. Only which player gets which card is left to chance.
+type RoleDistribution struct {
+	ConfigID    string              `json:"configId"`
+	Revision    int                 `json:"revision,omitempty"`
+	PlayerCount int                 `json:"playerCount"`
+	Buried      bool                `json:"buried"`    // One extra card is dealt face down and set aside
+	CardCount   int                 `json:"cardCount"` // Players plus the buried card
+	Teams       []*TeamDistribution `json:"teams"`     // RED, BLUE, GREY, ZOMBIE
+	Errors      []string            `json:"errors"`    // Problems that stop the game from starting
+	Warnings    []string            `json:"warnings"`  // Allowed, but likely to make an unbalanced game
+}
+
+// TeamDistribution lists the cards dealt to one team
+type TeamDistribution struct {
+	Team       config.TeamColor `json:"team"`
+	Size       int              `json:"size"`                 // Cards dealt to this team
+	Roles      []*RoleCardCount `json:"roles"`                // Role cards in dealing order (by priority)
+	Fill       int              `json:"fill"`                 // Remaining cards filled with the team's operative role
+	FillRoleID string           `json:"fillRoleId,omitempty"` // Operative role used for the fill
+
+	fillRole config.RoleDefinition
+}
+
+// RoleCardCount is the number of copies of one role card that are dealt
+type RoleCardCount struct {
+	RoleID string    `json:"roleId"`
+	Name   string    `json:"name"`
+	NameKo string    `json:"nameKo"`
+	Names  i18n.Text `json:"names,omitempty"` // Name by locale
+	Count  int       `json:"count"`
+
+	def config.RoleDefinition
+}
+
+// distributionTeams is the order teams are reported in
+var distributionTeams = []config.TeamColor{config.TeamRed, config.TeamBlue, config.TeamGrey, config.TeamZombie}
+
+// dealingOrder is the order teams take seats from the shuffled deck; Blue takes whatever is left
+var dealingOrder = []config.TeamColor{config.TeamZombie, config.TeamGrey, config.TeamRed, config.TeamBlue}
+
+// PlanRoleDistribution works out the cards dealt for a role configuration, player count and selection
+// With a selection only the selected roles are dealt, in the selected counts; without one every
+// role whose minPlayers is met is dealt with its configured count, except each team's operative
+// role (priority 99), which fills the seats left over instead.
+func PlanRoleDistribution(roleConfig *config.RoleConfig, playerCount int, selectedRoles map[string]int) *RoleDistribution {
+	plan := &RoleDistribution{
+		ConfigID:    roleConfig.ID,
+		Revision:    roleConfig.Revision,
+		PlayerCount: playerCount,
+		Errors:      []string{},
+		Warnings:    []string{},
+	}
+
+	if playerCount < 6 {
+		plan.Errors = append(plan.Errors, models.ErrMinimumPlayers.Error())
+	}
+
+	// Paired roles (e.g. Romeo and Juliet) must be selected together
+	if err := roleConfig.ValidateSelectedRoles(selectedRoles); err != nil {
+		plan.Errors = append(plan.Errors, errorMessages(err)...)
+	}
+
+	// Roles dealt, with their counts for this player count, and the operative roles filling the rest
+	var roleDefs, fillDefs []config.RoleDefinition
+	for _, roleDef := range roleConfig.Roles {
+		if len(selectedRoles) > 0 {
+			count, ok := selectedRoles[roleDef.ID]
+			if !ok || count <= 0 {
+				continue
+			}
+			if roleDef.MinPlayers > playerCount && playerCount >= 6 {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("role '%s' needs at least %d players, got %d", roleDef.ID, roleDef.MinPlayers, playerCount))
+			}
+			roleDef.Count = config.RoleCount{Fixed: &count}
+		} else if roleDef.MinPlayers > playerCount {
+			continue
+		} else if isFillRole(roleDef) {
+			fillDefs = append(fillDefs, roleDef)
+			continue
+		}
+		if roleDef.Count.GetCount(playerCount) > 0 {
+			roleDefs = append(roleDefs, roleDef)
+		}
+	}
+
+	plan.Buried = roleConfig.ShouldBury(playerCount, roleDefs)
+	plan.CardCount = playerCount
+	if plan.Buried {
+		plan.CardCount++
+	}
+
+	// Count role cards by team
+	teamCounts := make(map[config.TeamColor]int)
+	totalCount := 0
+	for _, roleDef := range roleDefs {
+		count := roleDef.Count.GetCount(playerCount)
+		teamCounts[roleDef.Team] += count
+		totalCount += count
+	}
+	if totalCount > plan.CardCount {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("too many roles selected (%d) for %d cards", totalCount, plan.CardCount))
+	}
+
+	// Seats go to Zombie, Grey and Red in turn as far as their role cards need; Blue takes the rest.
+	// When Red has operatives to fill with, Red and Blue split the seats left over evenly, Red
+	// taking the odd one, as far as Blue's role cards allow.
+	teams := make(map[config.TeamColor]*TeamDistribution)
+	remaining := plan.CardCount
+	for _, teamColor := range dealingOrder {
+		size := min(teamCounts[teamColor], remaining)
+		switch {
+		case teamColor == config.TeamBlue:
+			size = remaining
+		case teamColor == config.TeamRed && hasTeamRole(fillDefs, config.TeamRed):
+			size = max(size, min((remaining+1)/2, remaining-teamCounts[config.TeamBlue]))
+		}
+		remaining -= size
+		teams[teamColor] = planTeam(teamColor, size, roleDefs, fillDefs, playerCount)
+	}
+	for _, teamColor := range distributionTeams {
+		plan.Teams = append(plan.Teams, teams[teamColor])
+	}
+
+	// Both teams need a leader card, or their win condition cannot be decided
+	// (when roles already don't fit, a leader crowded out is not reported separately)
+	for _, teamColor := range []config.TeamColor{config.TeamRed, config.TeamBlue} {
+		if !teams[teamColor].hasLeader() && totalCount <= plan.CardCount {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("%s team has no leader", teamColor))
+		}
+	}
+
+	// Balance warnings only matter once the roles can be dealt
+	if len(plan.Errors) > 0 {
+		return plan
+	}
+	red, blue := teams[config.TeamRed].Size, teams[config.TeamBlue].Size
+	if diff := red - blue; diff > 1 || diff < -1 {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("RED team has %d cards and BLUE team has %d; select operatives to even them out", red, blue))
+	}
+	if grey := teams[config.TeamGrey].Size; grey*3 > plan.CardCount {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("%d of %d cards are Grey; more than a third leaves the teams small", grey, plan.CardCount))
+	}
+
+	return plan
+}
+
+// planTeam fills a team's seats with its role cards by priority, then with its operative role
+func planTeam(teamColor config.TeamColor, size int, roleDefs, fillDefs []config.RoleDefinition, playerCount int) *TeamDistribution {
+	team := &TeamDistribution{Team: teamColor, Size: size, Roles: []*RoleCardCount{}}
+
+	var teamDefs, teamFill []config.RoleDefinition
+	for _, roleDef := range roleDefs {
+		if roleDef.Team == teamColor {
+			teamDefs = append(teamDefs, roleDef)
+		}
+	}
+	for _, roleDef := range fillDefs {
+		if roleDef.Team == teamColor {
+			teamFill = append(teamFill, roleDef)
+		}
+	}
+
+	// Lower priority is dealt first; ties keep configuration order
+	sort.SliceStable(teamDefs, func(i, j int) bool {
+		return teamDefs[i].Priority < teamDefs[j].Priority
+	})
+
+	dealt := 0
+	for _, roleDef := range teamDefs {
+		count := min(roleDef.Count.GetCount(playerCount), size-dealt)
+		if count <= 0 {
+			break
+		}
+		team.Roles = append(team.Roles, &RoleCardCount{
+			RoleID: roleDef.ID,
+			Name:   roleDef.Name,
+			NameKo: roleDef.NameKo,
+			Names:  roleDef.NameText(),
+			Count:  count,
+			def:    roleDef,
+		})
+		dealt += count
+	}
+
+	team.Fill = size - dealt
+	if team.Fill > 0 {
+		team.fillRole = defaultOperativeRole(teamColor, append(teamFill, teamDefs...))
+		team.FillRoleID = team.fillRole.ID
+	}
+	return team
+}
+
+// cards returns one role definition per card dealt to the team
+func (t *TeamDistribution) cards() []config.RoleDefinition {
+	cards := make([]config.RoleDefinition, 0, t.Size)
+	for _, role := range t.Roles {
+		for i := 0; i < role.Count; i++ {
+			cards = append(cards, role.def)
+		}
+	}
+	for i := 0; i < t.Fill; i++ {
+		cards = append(cards, t.fillRole)
+	}
+	return cards
+}
+
+// hasLeader reports whether a leader card is dealt to the team
+func (t *TeamDistribution) hasLeader() bool {
+	for _, role := range t.Roles {
+		if role.def.Type == config.RoleTypeLeader {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the blocking errors as a single error, or nil if the roles can be dealt
+func (d *RoleDistribution) Err() error {
+	if len(d.Errors) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(d.Errors, "; "))
+}
+
+// defaultOperativeRole returns the team's operative role (priority 99) from the dealt roles,
+// or a basic operative role if the configuration has none
+func defaultOperativeRole(teamColor config.TeamColor, teamDefs []config.RoleDefinition) config.RoleDefinition {
+	for _, roleDef := range teamDefs {
+		if isFillRole(roleDef) {
+			return roleDef
+		}
+	}
+
+	var teamName, teamNameKo, description, descriptionKo, icon string
+	var names, descriptions i18n.Text // Other locales
+	switch teamColor {
+	case config.TeamRed:
+		teamName = "Red Team"
+		teamNameKo = "레드 팀원"
+		description = "Standard Red Team member"
+		descriptionKo = "레드 팀의 일반 요원"
+		names = i18n.Text{i18n.Japanese: "レッドチーム隊員"}
+		descriptions = i18n.Text{i18n.Japanese: "レッドチームの一般隊員"}
+		icon = "⭐"
+	case config.TeamBlue:
+		teamName = "Blue Team"
+		teamNameKo = "블루 팀원"
+		description = "Standard Blue Team member"
+		descriptionKo = "블루 팀의 일반 요원"
+		names = i18n.Text{i18n.Japanese: "ブルーチーム隊員"}
+		descriptions = i18n.Text{i18n.Japanese: "ブルーチームの一般隊員"}
+		icon = "⭐"
+	case config.TeamGrey:
+		teamName = "Grey Team"
+		teamNameKo = "그레이 팀원"
+		description = "Independent player"
+		descriptionKo = "독립 플레이어"
+		names = i18n.Text{i18n.Japanese: "グレーチーム隊員"}
+		descriptions = i18n.Text{i18n.Japanese: "独立したプレイヤー"}
+		icon = "⚪"
+	}
+
+	return config.RoleDefinition{
+		ID:            string(teamColor) + "_TEAM",
+		Name:          teamName,
+		NameKo:        teamNameKo,
+		Team:          teamColor,
+		Type:          config.RoleTypeOperative,
+		Description:   description,
+		DescriptionKo: descriptionKo,
+		Names:         names,
+		Descriptions:  descriptions,
+		Priority:      99,
+		Icon:          icon,
+	}
+}
+
+// isFillRole reports whether a role is a team's operative role (priority 99)
+// Without a selection it is dealt to the seats left over rather than in its configured count.
+func isFillRole(roleDef config.RoleDefinition) bool {
+	return roleDef.Type == config.RoleTypeOperative && roleDef.Priority == 99
+}
+
+// hasTeamRole reports whether any of the roles belongs to the team
+func hasTeamRole(roleDefs []config.RoleDefinition, teamColor config.TeamColor) bool {
+	for _, roleDef := range roleDefs {
+		if roleDef.Team == teamColor {
+			return true
+		}
+	}
+	return false
+}
+
+// errorMessages splits a joined error into its messages
+func errorMessages(err error) []string {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var messages []string
+		for _, e := range joined.Unwrap() {
+			messages = append(messages, errorMessages(e)...)
+		}
+		return messages
+	}
+	return []string{err.Error()}
+}
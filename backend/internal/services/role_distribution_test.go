@@ -0,0 +1,215 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// newDistributionTestConfig parses the bury test configuration with a SPY and a high minPlayers role added
+func newDistributionTestConfig(t *testing.T, bury config.BuryMode) *config.RoleConfig {
+	t.Helper()
+	var roleConfig config.RoleConfig
+	if err := json.Unmarshal([]byte(fmt.Sprintf(buryTestConfig, bury)), &roleConfig); err != nil {
+		t.Fatalf("Failed to parse config: %v", err)
+	}
+	one := 1
+	roleConfig.Roles = append(roleConfig.Roles,
+		config.RoleDefinition{ID: "RED_SPY", Team: config.TeamRed, Type: config.RoleTypeSpy, Count: config.RoleCount{Fixed: &one}, MinPlayers: 6, Priority: 2},
+		config.RoleDefinition{ID: "GAMBLER", Team: config.TeamGrey, Type: config.RoleTypeGrey, Count: config.RoleCount{Fixed: &one}, MinPlayers: 10, Priority: 3},
+	)
+	return &roleConfig
+}
+
+// loadShippedRoleConfigs loads the role configurations shipped in config/roles
+func loadShippedRoleConfigs(t *testing.T) *config.RoleConfigLoader {
+	t.Helper()
+	loader := config.NewRoleConfigLoader(filepath.Join("..", "..", "config", "roles"))
+	if err := loader.LoadAll(); err != nil {
+		t.Fatalf("Failed to load shipped role configs: %v", err)
+	}
+	return loader
+}
+
+// teamCards flattens a team's distribution into "ROLE:count" entries, fill last
+func teamCards(team *TeamDistribution) string {
+	var parts []string
+	for _, role := range team.Roles {
+		parts = append(parts, fmt.Sprintf("%s:%d", role.RoleID, role.Count))
+	}
+	if team.Fill > 0 {
+		parts = append(parts, fmt.Sprintf("%s:%d", team.FillRoleID, team.Fill))
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestPlanRoleDistribution(t *testing.T) {
+	tests := []struct {
+		name          string
+		bury          config.BuryMode
+		playerCount   int
+		selectedRoles map[string]int
+		wantTeams     map[config.TeamColor]string
+		wantBuried    bool
+		wantErrors    []string
+		wantWarnings  []string
+	}{
+		{
+			name:          "balanced selection",
+			bury:          config.BuryNever,
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_SPY": 1, "RED_TEAM": 2},
+			wantTeams: map[config.TeamColor]string{
+				config.TeamRed:  "BOMBER:1 RED_SPY:1 RED_TEAM:2",
+				config.TeamBlue: "PRESIDENT:1 BLUE_TEAM:3",
+			},
+		},
+		{
+			name:          "grey and buried card",
+			bury:          config.BuryOdd,
+			playerCount:   11,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "GAMBLER": 1, "RED_TEAM": 4},
+			wantBuried:    true,
+			wantTeams: map[config.TeamColor]string{
+				config.TeamRed:  "BOMBER:1 RED_TEAM:4",
+				config.TeamBlue: "PRESIDENT:1 BLUE_TEAM:5",
+				config.TeamGrey: "GAMBLER:1",
+			},
+		},
+		{
+			name:        "without a selection operatives fill evenly",
+			bury:        config.BuryNever,
+			playerCount: 8,
+			wantBuried:  true, // The Drunk always buries a card
+			wantTeams: map[config.TeamColor]string{
+				config.TeamRed:  "BOMBER:1 RED_SPY:1 RED_TEAM:2",
+				config.TeamBlue: "PRESIDENT:1 BLUE_TEAM:3",
+				config.TeamGrey: "DRUNK:1",
+			},
+		},
+		{
+			name:          "unbalanced teams warn",
+			bury:          config.BuryNever,
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1},
+			wantTeams: map[config.TeamColor]string{
+				config.TeamRed:  "BOMBER:1",
+				config.TeamBlue: "PRESIDENT:1 BLUE_TEAM:6",
+			},
+			wantWarnings: []string{"RED team has 1 cards and BLUE team has 7"},
+		},
+		{
+			name:          "missing leader",
+			bury:          config.BuryNever,
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "RED_TEAM": 4},
+			wantErrors:    []string{"RED team has no leader"},
+		},
+		{
+			name:          "too many roles",
+			bury:          config.BuryNever,
+			playerCount:   6,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_TEAM": 5},
+			wantErrors:    []string{"too many roles selected (7) for 6 cards"},
+		},
+		{
+			name:          "minPlayers violation",
+			bury:          config.BuryNever,
+			playerCount:   8,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1, "GAMBLER": 1, "RED_TEAM": 3},
+			wantErrors:    []string{"role 'GAMBLER' needs at least 10 players, got 8"},
+		},
+		{
+			name:          "too few players",
+			bury:          config.BuryNever,
+			playerCount:   4,
+			selectedRoles: map[string]int{"PRESIDENT": 1, "BOMBER": 1},
+			wantErrors:    []string{models.ErrMinimumPlayers.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanRoleDistribution(newDistributionTestConfig(t, tt.bury), tt.playerCount, tt.selectedRoles)
+
+			if plan.Buried != tt.wantBuried {
+				t.Errorf("Expected buried=%v, got %v", tt.wantBuried, plan.Buried)
+			}
+			for _, team := range plan.Teams {
+				if got := teamCards(team); got != tt.wantTeams[team.Team] && tt.wantErrors == nil {
+					t.Errorf("Expected %s cards %q, got %q", team.Team, tt.wantTeams[team.Team], got)
+				}
+			}
+			assertMessages(t, "error", plan.Errors, tt.wantErrors)
+			assertMessages(t, "warning", plan.Warnings, tt.wantWarnings)
+			if (plan.Err() != nil) != (len(tt.wantErrors) > 0) {
+				t.Errorf("Expected Err() to match errors, got %v", plan.Err())
+			}
+		})
+	}
+}
+
+func assertMessages(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d %s(s) %v, got %v", len(want), kind, want, got)
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Errorf("Expected %s containing %q, got %q", kind, want[i], got[i])
+		}
+	}
+}
+
+func TestAssignRolesFromConfig_FollowsPlan(t *testing.T) {
+	roleConfig := newDistributionTestConfig(t, config.BuryNever)
+	selected := map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_SPY": 1, "RED_TEAM": 2}
+	plan := PlanRoleDistribution(roleConfig, 8, selected)
+
+	want := make(map[string]int)
+	for _, team := range plan.Teams {
+		for _, roleDef := range team.cards() {
+			want[roleDef.ID]++
+		}
+	}
+
+	players := newBuryTestPlayers(8)
+	if _, err := NewGameService(nil, nil).assignRolesFromConfig(players, roleConfig, selected); err != nil {
+		t.Fatalf("assignRolesFromConfig failed: %v", err)
+	}
+	got := make(map[string]int)
+	for _, player := range players {
+		got[player.Role.ID]++
+		if player.Team != player.Role.Team {
+			t.Errorf("Player %s has team %s but role team %s", player.ID, player.Team, player.Role.Team)
+		}
+	}
+	for roleID, count := range want {
+		if got[roleID] != count {
+			t.Errorf("Expected %d %s, got %d", count, roleID, got[roleID])
+		}
+	}
+}
+
+func TestPlanRoleDistribution_ShippedDefault(t *testing.T) {
+	roleConfig, err := loadShippedRoleConfigs(t).Get("standard")
+	if err != nil {
+		t.Fatalf("Failed to get standard config: %v", err)
+	}
+
+	for players := 6; players <= 30; players++ {
+		plan := PlanRoleDistribution(roleConfig, players, nil)
+		if err := plan.Err(); err != nil {
+			t.Errorf("%d players: expected the default roles to be dealt, got %v", players, err)
+		}
+		red, blue := plan.Teams[0].Size, plan.Teams[1].Size
+		if diff := red - blue; diff < 0 || diff > 1 {
+			t.Errorf("%d players: expected even teams, got RED %d and BLUE %d", players, red, blue)
+		}
+	}
+}
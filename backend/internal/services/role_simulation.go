@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// RoleSimulation reports the problems found by dealing a configuration's roles many times
+type RoleSimulation struct {
+	ConfigID    string
+	PlayerCount int
+	Runs        int
+	Violations  []*RoleInvariantViolation // In the order first seen
+}
+
+// RoleInvariantViolation is a problem found in simulated deals, counted over every run
+type RoleInvariantViolation struct {
+	Message  string
+	FirstRun int // Run the problem was first seen in, from 0
+	Runs     int // Runs the problem was seen in
+}
+
+// SimulateRoleAssignments deals a configuration's roles to playerCount players runs times
+// through AssignRolesWithConfig and checks every deal (see checkRoleDeal)
+// Set a seeded random source with SetRand to make the simulation repeatable.
+func (s *GameService) SimulateRoleAssignments(configID string, playerCount int, selectedRoles map[string]int, runs int) (*RoleSimulation, error) {
+	roleConfig, err := s.roleLoader.Get(configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role config: %w", err)
+	}
+	plan := PlanRoleDistribution(roleConfig, playerCount, selectedRoles)
+	if err := plan.Err(); err != nil {
+		return nil, err
+	}
+
+	simulation := &RoleSimulation{ConfigID: configID, PlayerCount: playerCount, Runs: runs}
+	seen := make(map[string]*RoleInvariantViolation)
+	for run := 0; run < runs; run++ {
+		players := make([]*models.Player, playerCount)
+		for i := range players {
+			players[i] = &models.Player{ID: fmt.Sprintf("player-%d", i+1)}
+		}
+
+		var problems []string
+		buried, err := s.AssignRolesWithConfig(players, configID, selectedRoles)
+		if err != nil {
+			problems = []string{err.Error()}
+		} else {
+			problems = checkRoleDeal(roleConfig, plan, players, buried)
+		}
+
+		for _, problem := range problems {
+			if violation, ok := seen[problem]; ok {
+				violation.Runs++
+				continue
+			}
+			seen[problem] = &RoleInvariantViolation{Message: problem, FirstRun: run, Runs: 1}
+			simulation.Violations = append(simulation.Violations, seen[problem])
+		}
+	}
+	return simulation, nil
+}
+
+// checkRoleDeal checks the rules every deal must follow
+// Every player holds a card, the cards are the ones planned, each team's leader is in play
+// (or buried with someone to stand in), the Red and Blue teams differ by at most one card
+// and required partner roles are dealt together.
+func checkRoleDeal(roleConfig *config.RoleConfig, plan *RoleDistribution, players []*models.Player, buried *models.Role) []string {
+	var problems []string
+
+	// Cards dealt, buried card included
+	dealt := make(map[string]int)
+	teamSizes := make(map[models.TeamColor]int)
+	for _, player := range players {
+		if player.Role == nil {
+			problems = append(problems, "a player was dealt no card")
+			continue
+		}
+		if player.Team != player.Role.Team {
+			problems = append(problems, fmt.Sprintf("a %s holder is on team %s", player.Role.ID, player.Team))
+		}
+		dealt[player.Role.ID]++
+		teamSizes[player.Team]++
+	}
+	if plan.Buried != (buried != nil) {
+		problems = append(problems, fmt.Sprintf("expected buried=%v, got buried=%v", plan.Buried, buried != nil))
+	}
+	if buried != nil {
+		dealt[buried.ID]++
+		teamSizes[buried.Team]++ // Burying takes a card from either team at random
+		if buried.SwapBuriedRound > 0 {
+			problems = append(problems, fmt.Sprintf("%s was buried", buried.ID))
+		}
+	}
+
+	// The cards are exactly the planned ones
+	planned := make(map[string]int)
+	for _, team := range plan.Teams {
+		for _, card := range team.cards() {
+			planned[card.ID]++
+		}
+	}
+	for _, roleDef := range roleConfig.Roles {
+		if dealt[roleDef.ID] != planned[roleDef.ID] {
+			problems = append(problems, fmt.Sprintf("%s dealt %d time(s), planned %d", roleDef.ID, dealt[roleDef.ID], planned[roleDef.ID]))
+		}
+	}
+
+	// Both leaders are in play, or buried with a backup or Drunk to take over
+	for _, teamColor := range []models.TeamColor{models.TeamRed, models.TeamBlue} {
+		if !leaderInPlay(teamColor, players, buried) {
+			problems = append(problems, fmt.Sprintf("%s team has no leader in play", teamColor))
+		}
+	}
+
+	if red, blue := teamSizes[models.TeamRed], teamSizes[models.TeamBlue]; red-blue > 1 || blue-red > 1 {
+		problems = append(problems, fmt.Sprintf("RED team has %d cards and BLUE team has %d", red, blue))
+	}
+
+	// Required partners are dealt together (a partner may be the buried card)
+	for _, roleDef := range roleConfig.Roles {
+		if dealt[roleDef.ID] == 0 {
+			continue
+		}
+		for _, requiredID := range roleDef.RequiresRoles {
+			if dealt[requiredID] == 0 {
+				problems = append(problems, fmt.Sprintf("%s dealt without %s", roleDef.ID, requiredID))
+			}
+		}
+	}
+
+	return problems
+}
+
+// leaderInPlay reports whether a player leads the team, or someone takes over for a buried leader
+func leaderInPlay(teamColor models.TeamColor, players []*models.Player, buried *models.Role) bool {
+	for _, player := range players {
+		if player.Role != nil && player.Role.IsLeader && player.Role.Team == teamColor {
+			return true
+		}
+	}
+	if buried == nil || !buried.IsLeader || buried.Team != teamColor {
+		return false
+	}
+	return !mustNotBury(buried, players)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+func TestGameService_SimulateRoleAssignments(t *testing.T) {
+	gameService := newBuryTestService(t, config.BuryOdd)
+	selected := map[string]int{"PRESIDENT": 1, "BOMBER": 1, "BLUE_TEAM": 3, "RED_TEAM": 3, "DRUNK": 1}
+
+	gameService.SetRand(rand.New(rand.NewSource(1)))
+	simulation, err := gameService.SimulateRoleAssignments("bury-test", 9, selected, 200)
+	if err != nil {
+		t.Fatalf("SimulateRoleAssignments failed: %v", err)
+	}
+	for _, violation := range simulation.Violations {
+		t.Errorf("Unexpected violation: %s (%d runs)", violation.Message, violation.Runs)
+	}
+
+	// A selection that cannot be dealt is not simulated
+	if _, err := gameService.SimulateRoleAssignments("bury-test", 9, map[string]int{"BOMBER": 1}, 10); err == nil {
+		t.Error("Expected an error for a selection without a President")
+	}
+}
+
+func TestGameService_SetRand(t *testing.T) {
+	deal := func() string {
+		gameService := newBuryTestService(t, config.BuryNever)
+		gameService.SetRand(rand.New(rand.NewSource(42)))
+		players := newBuryTestPlayers(8)
+		buried, err := gameService.AssignRolesWithConfig(players, "bury-test", map[string]int{"PRESIDENT": 1, "BOMBER": 1, "DRUNK": 1})
+		if err != nil {
+			t.Fatalf("AssignRolesWithConfig failed: %v", err)
+		}
+		var roles []string
+		for _, player := range players {
+			roles = append(roles, player.Role.ID)
+		}
+		return strings.Join(roles, " ") + " buried:" + buried.ID
+	}
+
+	if first, second := deal(), deal(); first != second {
+		t.Errorf("Expected the same seed to deal the same roles, got %q and %q", first, second)
+	}
+}
+
+func TestCheckRoleDeal(t *testing.T) {
+	roleConfig := newDistributionTestConfig(t, config.BuryNever)
+	selected := map[string]int{"PRESIDENT": 1, "BOMBER": 1, "RED_SPY": 1, "RED_TEAM": 1, "BLUE_TEAM": 2}
+	plan := PlanRoleDistribution(roleConfig, 6, selected)
+
+	tests := []struct {
+		name    string
+		tamper  func(players []*models.Player)
+		wantErr []string
+	}{
+		{name: "valid deal"},
+		{
+			name: "leader replaced",
+			tamper: func(players []*models.Player) {
+				for _, player := range players {
+					if player.Role.ID == "BOMBER" {
+						operative := *player.Role
+						operative.ID, operative.IsLeader = "RED_TEAM", false
+						player.Role = &operative
+					}
+				}
+			},
+			wantErr: []string{"BOMBER dealt 0 time(s), planned 1", "RED_TEAM dealt 2 time(s), planned 1", "RED team has no leader in play"},
+		},
+		{
+			name: "player moved to the other team",
+			tamper: func(players []*models.Player) {
+				for _, player := range players {
+					if player.Role.ID == "RED_SPY" {
+						player.Team = models.TeamBlue
+					}
+				}
+			},
+			wantErr: []string{"a RED_SPY holder is on team BLUE", "RED team has 2 cards and BLUE team has 4"},
+		},
+		{
+			name:    "player without a card",
+			tamper:  func(players []*models.Player) { players[0].Role = nil },
+			wantErr: []string{"a player was dealt no card"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := newBuryTestPlayers(6)
+			if _, err := NewGameService(nil, nil).assignRolesFromConfig(players, roleConfig, selected); err != nil {
+				t.Fatalf("assignRolesFromConfig failed: %v", err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(players)
+			}
+
+			problems := strings.Join(checkRoleDeal(roleConfig, plan, players, nil), "; ")
+			if len(tt.wantErr) == 0 && problems != "" {
+				t.Errorf("Expected no problems, got %q", problems)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(problems, want) {
+					t.Errorf("Expected %q among the problems, got %q", want, problems)
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package services
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"math/rand"
 	"time"
 
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+	ws "github.com/kalee/two-rooms-and-a-boom/internal/websocket"
 )
 
 const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -25,7 +29,9 @@ func GenerateRoomCode() string {
 
 // RoomService handles room-related business logic
 type RoomService struct {
-	roomStore *store.RoomStore
+	roomStore  *store.RoomStore
+	roleLoader *config.RoleConfigLoader
+	hub        *ws.Hub
 }
 
 // NewRoomService creates a new RoomService instance
@@ -35,8 +41,37 @@ func NewRoomService(roomStore *store.RoomStore) *RoomService {
 	}
 }
 
+// SetRoleLoader sets the role configuration loader used to pin each room's configuration revision
+func (s *RoomService) SetRoleLoader(roleLoader *config.RoleConfigLoader) {
+	s.roleLoader = roleLoader
+}
+
+// SetHub sets the WebSocket hub used to tell the lobby about room changes
+func (s *RoomService) SetHub(hub *ws.Hub) {
+	s.hub = hub
+}
+
 // T035: Implement RoomService.CreateRoom
 func (s *RoomService) CreateRoom(maxPlayers int, isPublic bool, roleConfigID string, selectedRoles map[string]int) (*models.Room, error) {
+	return s.createRoom(maxPlayers, isPublic, roleConfigID, nil, selectedRoles)
+}
+
+// CreateCustomRoom creates a room that plays with its own inline role set
+// The role set is checked with the same rules as configuration files.
+func (s *RoomService) CreateCustomRoom(maxPlayers int, isPublic bool, roleSet *config.RoleConfig, selectedRoles map[string]int) (*models.Room, error) {
+	roleSet, err := s.prepareRoleSet(roleSet, selectedRoles)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(roleSet)
+	if err != nil {
+		return nil, err
+	}
+	return s.createRoom(maxPlayers, isPublic, roleSet.ID, data, selectedRoles)
+}
+
+// createRoom creates and stores a room; roleSet is nil unless the room has an inline role set
+func (s *RoomService) createRoom(maxPlayers int, isPublic bool, roleConfigID string, roleSet json.RawMessage, selectedRoles map[string]int) (*models.Room, error) {
 	// Validate maxPlayers range (6-30)
 	if maxPlayers < 6 || maxPlayers > 30 {
 		return nil, errors.New("maxPlayers must be between 6 and 30")
@@ -67,15 +102,23 @@ func (s *RoomService) CreateRoom(maxPlayers int, isPublic bool, roleConfigID str
 	// Create room
 	now := time.Now()
 	room := &models.Room{
-		Code:          roomCode,
-		Status:        models.RoomStatusWaiting,
-		Players:       []*models.Player{},
-		MaxPlayers:    maxPlayers,
-		IsPublic:      isPublic,
-		RoleConfigID:  roleConfigID,
-		SelectedRoles: selectedRoles,
-		CreatedAt:     now,
-		UpdatedAt:     now,
+		Code:             roomCode,
+		Status:           models.RoomStatusWaiting,
+		Players:          []*models.Player{},
+		MaxPlayers:       maxPlayers,
+		IsPublic:         isPublic,
+		RoleConfigID:     roleConfigID,
+		SelectedRoles:    selectedRoles,
+		CustomRoleConfig: roleSet,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	// Keep the configuration revision current at creation, so later edits don't change this room's game
+	if roleSet == nil && s.roleLoader != nil {
+		if roleConfig, err := s.roleLoader.Get(roleConfigID); err == nil {
+			room.RoleConfigRevision = roleConfig.Revision
+		}
 	}
 
 	// Store room
@@ -85,7 +128,7 @@ func (s *RoomService) CreateRoom(maxPlayers int, isPublic bool, roleConfigID str
 	}
 
 	// T103: Log critical operation
-	log.Printf("[INFO] Room created: code=%s maxPlayers=%d isPublic=%v roleConfig=%s selectedRolesCount=%d", room.Code, room.MaxPlayers, room.IsPublic, room.RoleConfigID, len(room.SelectedRoles))
+	log.Printf("[INFO] Room created: code=%s maxPlayers=%d isPublic=%v roleConfig=%s revision=%d selectedRolesCount=%d", room.Code, room.MaxPlayers, room.IsPublic, room.RoleConfigID, room.RoleConfigRevision, len(room.SelectedRoles))
 
 	return room, nil
 }
@@ -221,3 +264,137 @@ func (s *RoomService) UpdateRoomVisibility(roomCode string, playerID string, isP
 
 	return updatedRoom, nil
 }
+
+// UpdateRoleSet changes the roles a room plays with while it is in the lobby
+// With a roleSet the room uses that inline set; otherwise it uses the server-wide
+// configuration roleConfigID at its current revision.
+func (s *RoomService) UpdateRoleSet(roomCode, playerID, roleConfigID string, roleSet *config.RoleConfig, selectedRoles map[string]int) (*models.Room, error) {
+	room, err := s.roomStore.Get(roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the player is the room owner
+	var isOwner bool
+	for _, player := range room.Players {
+		if player.ID == playerID && player.IsOwner {
+			isOwner = true
+			break
+		}
+	}
+	if !isOwner {
+		return nil, models.ErrNotRoomOwner
+	}
+	if room.Status != models.RoomStatusWaiting {
+		return nil, models.ErrGameAlreadyStarted
+	}
+
+	revision := 0
+	var roleSetData json.RawMessage
+	if roleSet != nil {
+		roleSet, err = s.prepareRoleSet(roleSet, selectedRoles)
+		if err != nil {
+			return nil, err
+		}
+		if roleSetData, err = json.Marshal(roleSet); err != nil {
+			return nil, err
+		}
+		roleConfigID = roleSet.ID
+	} else {
+		if roleConfigID == "" {
+			roleConfigID = config.DefaultConfigID
+		}
+		if s.roleLoader == nil {
+			return nil, fmt.Errorf("%w: %s", config.ErrConfigNotFound, roleConfigID)
+		}
+		roleConfig, err := s.roleLoader.Get(roleConfigID)
+		if err != nil {
+			return nil, err
+		}
+		if err := roleConfig.ValidateSelectedRoles(selectedRoles); err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidRoleSelection, err)
+		}
+		revision = roleConfig.Revision
+	}
+
+	room.RoleConfigID = roleConfigID
+	room.RoleConfigRevision = revision
+	room.CustomRoleConfig = roleSetData
+	room.SelectedRoles = selectedRoles
+	if err := s.roomStore.Update(room); err != nil {
+		log.Printf("[ERROR] Failed to update room role set: %v", err)
+		return nil, err
+	}
+
+	log.Printf("[INFO] Room role set updated: code=%s roleConfig=%s custom=%v selectedRolesCount=%d", roomCode, roleConfigID, roleSet != nil, len(selectedRoles))
+
+	// Let everyone in the lobby see the new roles and preview them
+	if s.hub != nil {
+		payload := &ws.RoleSetChangedPayload{
+			RoleConfigID:       room.RoleConfigID,
+			RoleConfigRevision: room.RoleConfigRevision,
+			SelectedRoles:      room.SelectedRoles,
+			CustomRoleConfig:   room.CustomRoleConfig,
+		}
+		if err := s.hub.BroadcastRoleSetChanged(roomCode, payload); err != nil {
+			log.Printf("[WARN] Failed to broadcast ROLE_SET_CHANGED: %v", err)
+		}
+	}
+
+	return room, nil
+}
+
+// maxCustomRoles limits the size of a room's inline role set
+const maxCustomRoles = 100
+
+// prepareRoleSet fills in the metadata a host may leave out of an inline role set, resolves
+// its extends against the loaded configurations and validates it
+func (s *RoomService) prepareRoleSet(roleSet *config.RoleConfig, selectedRoles map[string]int) (*config.RoleConfig, error) {
+	if roleSet.ID == "" {
+		roleSet.ID = "custom"
+	}
+	if roleSet.Name == "" {
+		roleSet.Name = "Custom"
+	}
+	if roleSet.Version == "" {
+		roleSet.Version = "1.0.0"
+	}
+	roleSet.Revision = 0
+
+	if len(roleSet.Roles)+len(roleSet.Overrides) > maxCustomRoles {
+		return nil, fmt.Errorf("%w: a role set may define at most %d roles", config.ErrInvalidConfig, maxCustomRoles)
+	}
+
+	resolver := s.roleLoader
+	if resolver == nil {
+		resolver = config.NewRoleConfigLoader("") // Nothing loaded to extend
+	}
+	roleSet, err := resolver.Resolve(roleSet)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleSet.Roles) > maxCustomRoles {
+		return nil, fmt.Errorf("%w: a role set may define at most %d roles", config.ErrInvalidConfig, maxCustomRoles)
+	}
+
+	if err := roleSet.Validate(); err != nil {
+		return nil, err
+	}
+	if err := roleSet.ValidateSelectedRoles(selectedRoles); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidRoleSelection, err)
+	}
+	return roleSet, nil
+}
+
+// customRoleSet decodes a room's inline role set, or returns nil if the room has none
+// Rooms keep the resolved set as JSON, so models stay independent of the config package.
+func customRoleSet(room *models.Room) (*config.RoleConfig, error) {
+	if room.CustomRoleConfig == nil {
+		return nil, nil
+	}
+	var roleSet config.RoleConfig
+	if err := json.Unmarshal(room.CustomRoleConfig, &roleSet); err != nil {
+		return nil, fmt.Errorf("invalid inline role set in room %s: %w", room.Code, err)
+	}
+	return &roleSet, nil
+}
@@ -179,7 +179,7 @@ func TestRoomService_CreateRoom_WithIsPublic(t *testing.T) {
 	service := NewRoomService(roomStore)
 
 	t.Run("Create public room", func(t *testing.T) {
-		room, err := service.CreateRoom(10, true)
+		room, err := service.CreateRoom(10, true, "standard", nil)
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
@@ -194,7 +194,7 @@ func TestRoomService_CreateRoom_WithIsPublic(t *testing.T) {
 	})
 
 	t.Run("Create private room", func(t *testing.T) {
-		room, err := service.CreateRoom(10, false)
+		room, err := service.CreateRoom(10, false, "standard", nil)
 		if err != nil {
 			t.Fatalf("Expected no error, got %v", err)
 		}
@@ -205,7 +205,7 @@ func TestRoomService_CreateRoom_WithIsPublic(t *testing.T) {
 	})
 
 	t.Run("Private room not in public list", func(t *testing.T) {
-		privateRoom, _ := service.CreateRoom(10, false)
+		privateRoom, _ := service.CreateRoom(10, false, "standard", nil)
 
 		response, err := service.GetPublicRooms("", 50, 0)
 		if err != nil {
@@ -220,7 +220,7 @@ func TestRoomService_CreateRoom_WithIsPublic(t *testing.T) {
 	})
 
 	t.Run("Public room appears in public list", func(t *testing.T) {
-		publicRoom, _ := service.CreateRoom(10, true)
+		publicRoom, _ := service.CreateRoom(10, true, "standard", nil)
 
 		response, err := service.GetPublicRooms("", 50, 0)
 		if err != nil {
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/store"
 	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
@@ -24,16 +25,24 @@ type RoundManager struct {
 	hub           *websocket.Hub
 	store         *store.RoomStore
 	leaderService *LeaderService
+	votingService *VotingService
 	timers        map[string]*RoundTimer // sessionID -> timer
 	mu            sync.RWMutex
+
+	// Final actions phase (Sniper, Gambler) after round 3
+	finalActionDuration time.Duration
+	finalTimers         map[string]*time.Timer // sessionID -> deadline timer
+	finalMu             sync.Mutex
 }
 
 // NewRoundManager creates a new RoundManager instance
 func NewRoundManager(hub *websocket.Hub, store *store.RoomStore) *RoundManager {
 	return &RoundManager{
-		hub:    hub,
-		store:  store,
-		timers: make(map[string]*RoundTimer),
+		hub:                 hub,
+		store:               store,
+		timers:              make(map[string]*RoundTimer),
+		finalActionDuration: defaultFinalActionDuration,
+		finalTimers:         make(map[string]*time.Timer),
 	}
 }
 
@@ -42,6 +51,11 @@ func (rm *RoundManager) SetLeaderService(ls *LeaderService) {
 	rm.leaderService = ls
 }
 
+// SetVotingService sets the voting service (used to cancel votes when a game ends early)
+func (rm *RoundManager) SetVotingService(vs *VotingService) {
+	rm.votingService = vs
+}
+
 // StartRound starts a new round with timer
 func (rm *RoundManager) StartRound(roomCode string, roundNumber int) error {
 	// Get room
@@ -94,6 +108,9 @@ func (rm *RoundManager) StartRound(roomCode string, roundNumber int) error {
 	room.GameSession.CurrentRound = roundNumber
 	room.GameSession.RoundState = roundState
 
+	// Roles that trade with the buried card do so at the start of their round (Drunk)
+	swapped := swapBuriedCards(room, roundNumber)
+
 	if err := rm.store.Update(room); err != nil {
 		return err
 	}
@@ -106,6 +123,10 @@ func (rm *RoundManager) StartRound(roomCode string, roundNumber int) error {
 		log.Printf("[ERROR] Failed to broadcast ROUND_STARTED: %v", err)
 	}
 
+	for _, player := range swapped {
+		rm.sendRoleAssigned(roomCode, player)
+	}
+
 	// Start timer goroutine
 	if err := rm.startTimer(roomCode, sessionID, roundNumber); err != nil {
 		return err
@@ -301,7 +322,10 @@ func (rm *RoundManager) EndRound(roomCode string) error {
 	finalRound := roundState.RoundNumber == 3
 	nextPhase := "ROUND_SETUP"
 	if finalRound {
-		nextPhase = "REVEALING"
+		nextPhase = string(models.RoomStatusRevealing)
+		if len(finalActors(room)) > 0 {
+			nextPhase = string(models.RoomStatusFinalActions)
+		}
 	}
 
 	payload := &websocket.RoundEndedPayload{
@@ -419,9 +443,13 @@ func (rm *RoundManager) LeaderReady(roomCode, leaderID string) error {
 		log.Printf("[INFO] Both leaders ready: room=%s round=%d", roomCode, roundState.RoundNumber)
 
 		if roundState.RoundNumber == 3 {
-			// After Round 3, transition to REVEALING
-			log.Printf("[INFO] Round 3 complete, transitioning to REVEALING: room=%s", roomCode)
-			go rm.transitionToRevealing(roomCode)
+			// After Round 3, collect final actions (if any) and transition to REVEALING
+			log.Printf("[INFO] Round 3 complete, finishing game: room=%s", roomCode)
+			go func() {
+				if err := rm.finishGame(roomCode); err != nil {
+					log.Printf("[ERROR] Failed to finish game: %v", err)
+				}
+			}()
 		} else {
 			// Start next round
 			nextRound := roundState.RoundNumber + 1
@@ -458,6 +486,15 @@ func (rm *RoundManager) transitionToRevealing(roomCode string) error {
 		return errors.New("no active game session")
 	}
 
+	// An early end may already have revealed this game
+	if room.Status == models.RoomStatusRevealing {
+		return nil
+	}
+
+	// Resolve win conditions before revealing roles
+	outcome := ResolveOutcome(room)
+	room.GameSession.Outcome = outcome
+
 	// Update game status
 	room.Status = models.RoomStatusRevealing
 
@@ -465,20 +502,64 @@ func (rm *RoundManager) transitionToRevealing(roomCode string) error {
 		return err
 	}
 
-	log.Printf("[INFO] Game transitioned to REVEALING: room=%s", roomCode)
+	log.Printf("[INFO] Game transitioned to REVEALING: room=%s winner=%s", roomCode, outcome.WinningTeam)
 
-	// Broadcast GAME_REVEALING event
-	payload := &websocket.GameRevealingPayload{
-		Message: "모든 라운드가 종료되었습니다. 역할 공개 단계로 이동합니다.",
+	// Broadcast GAME_REVEALING event, in each player's language
+	message := i18n.Msg("game.revealing")
+	if outcome.EarlyEnd != nil {
+		message = i18n.Msg("game.revealing.early_end")
 	}
 
-	msg, _ := websocket.NewMessage(websocket.MessageGameRevealing, payload)
-	data, _ := msg.Marshal()
-	rm.hub.BroadcastToRoom(roomCode, data)
+	if rm.hub == nil {
+		return nil
+	}
+
+	rm.hub.BroadcastLocalized(roomCode, func(locale string) (*websocket.Message, error) {
+		return websocket.NewMessage(websocket.MessageGameRevealing, &websocket.GameRevealingPayload{
+			Message: message.Render(locale),
+			Outcome: outcome.Localized(locale),
+		})
+	})
 
 	return nil
 }
 
+// EndGameEarly ends the game instantly from any phase (e.g. Dr. Boom)
+// Stops the round timer, cancels active votes, records which room gains
+// the "dead" condition and goes straight to outcome resolution and reveal.
+func (rm *RoundManager) EndGameEarly(roomCode string, end *models.EarlyEnd) error {
+	room, err := rm.store.Get(roomCode)
+	if err != nil {
+		return err
+	}
+
+	if room.Status != models.RoomStatusInProgress || room.GameSession == nil {
+		return errors.New("game is not in progress")
+	}
+
+	rm.stopTimer(room.GameSession.ID)
+
+	if rm.votingService != nil {
+		rm.votingService.CancelRoomVotes(roomCode)
+	}
+
+	if roundState := room.GameSession.RoundState; roundState != nil {
+		now := time.Now()
+		roundState.EndedAt = &now
+		roundState.Status = models.RoundStatusComplete
+	}
+	room.GameSession.EarlyEnd = end
+
+	if err := rm.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Game ended early: room=%s reason=%s triggeredBy=%s room=%s",
+		roomCode, end.Reason, end.TriggeredBy, end.Room)
+
+	return rm.transitionToRevealing(roomCode)
+}
+
 // Cleanup stops all timers (called on shutdown)
 func (rm *RoundManager) Cleanup() {
 	rm.mu.Lock()
@@ -0,0 +1,504 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
+)
+
+// ShareService handles card and color shares between players
+type ShareService struct {
+	store        *store.RoomStore
+	hub          *websocket.Hub
+	roundManager *RoundManager
+	mu           sync.Mutex
+}
+
+// NewShareService creates a new ShareService instance
+func NewShareService(store *store.RoomStore, hub *websocket.Hub) *ShareService {
+	return &ShareService{
+		store: store,
+		hub:   hub,
+	}
+}
+
+// SetRoundManager sets the RoundManager (used by card share powers that end the game)
+func (ss *ShareService) SetRoundManager(rm *RoundManager) {
+	ss.roundManager = rm
+}
+
+// RequestShare offers a card or color share to another player in the same room
+func (ss *ShareService) RequestShare(roomCode, initiatorID, targetID string, shareType models.ShareType) (*models.ShareRecord, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if shareType != models.ShareTypeCard && shareType != models.ShareTypeColor {
+		return nil, errors.New("invalid share type")
+	}
+
+	room, err := ss.store.Get(roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Status != models.RoomStatusInProgress || room.GameSession == nil {
+		return nil, errors.New("game is not in progress")
+	}
+
+	if initiatorID == targetID {
+		return nil, errors.New("cannot share with yourself")
+	}
+
+	initiator, target := findPlayer(room, initiatorID), findPlayer(room, targetID)
+	if initiator == nil || target == nil {
+		return nil, models.ErrPlayerNotFound
+	}
+
+	if initiator.CurrentRoom != target.CurrentRoom {
+		return nil, errors.New("can only share with players in your room")
+	}
+
+	if err := checkShareConditions(room, shareType, initiator, target); err != nil {
+		return nil, err
+	}
+
+	// Only one pending request between the same two players at a time
+	for _, share := range room.GameSession.Shares {
+		if share.Status == models.ShareStatusPending && share.Involves(initiatorID) && share.Involves(targetID) {
+			return nil, errors.New("share request already pending")
+		}
+	}
+
+	share := &models.ShareRecord{
+		ID:          uuid.New().String(),
+		Type:        shareType,
+		InitiatorID: initiatorID,
+		TargetID:    targetID,
+		RoundNumber: room.GameSession.CurrentRound,
+		Status:      models.ShareStatusPending,
+		RequestedAt: time.Now(),
+	}
+	room.GameSession.Shares = append(room.GameSession.Shares, share)
+
+	if err := ss.store.Update(room); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] Share requested: room=%s type=%s initiator=%s target=%s",
+		roomCode, shareType, initiatorID, targetID)
+
+	ss.sendToPlayer(roomCode, targetID, websocket.MessageShareRequested, &websocket.ShareRequestedPayload{
+		ShareID:   share.ID,
+		ShareType: shareType,
+		Initiator: &websocket.LeaderInfo{ID: initiator.ID, Nickname: initiator.Nickname},
+	})
+
+	return share, nil
+}
+
+// RespondToShare accepts or declines a pending share request
+// Only the target of the request may respond
+func (ss *ShareService) RespondToShare(roomCode, shareID, playerID string, accept bool) (*models.ShareRecord, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	room, err := ss.store.Get(roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Status != models.RoomStatusInProgress || room.GameSession == nil {
+		return nil, errors.New("game is not in progress")
+	}
+
+	var share *models.ShareRecord
+	for _, s := range room.GameSession.Shares {
+		if s.ID == shareID {
+			share = s
+			break
+		}
+	}
+
+	if share == nil {
+		return nil, errors.New("share request not found")
+	}
+
+	if share.TargetID != playerID {
+		return nil, errors.New("only the requested player can respond")
+	}
+
+	if share.Status != models.ShareStatusPending {
+		return nil, errors.New("share request already answered")
+	}
+
+	initiator, target := findPlayer(room, share.InitiatorID), findPlayer(room, share.TargetID)
+	if initiator == nil || target == nil {
+		return nil, models.ErrPlayerNotFound
+	}
+
+	// Foolish players must accept whatever is offered, unless the share can no longer happen
+	if !accept && target.HasCondition(models.ConditionFoolish) &&
+		initiator.CurrentRoom == target.CurrentRoom && checkShareConditions(room, share.Type, initiator, target) == nil {
+		return nil, &models.ShareError{Code: models.ShareRejectedFoolish, PlayerID: target.ID}
+	}
+
+	now := time.Now()
+
+	if !accept {
+		share.CompletedAt = &now
+		share.Status = models.ShareStatusDeclined
+		if err := ss.store.Update(room); err != nil {
+			return nil, err
+		}
+
+		log.Printf("[INFO] Share declined: room=%s share=%s", roomCode, shareID)
+
+		ss.sendToPlayer(roomCode, initiator.ID, websocket.MessageShareDeclined, &websocket.ShareDeclinedPayload{
+			ShareID: share.ID,
+			Target:  &websocket.LeaderInfo{ID: target.ID, Nickname: target.Nickname},
+		})
+		return share, nil
+	}
+
+	// Hostage exchanges may have split the players since the request was made
+	if initiator.CurrentRoom != target.CurrentRoom {
+		return nil, errors.New("can only share with players in your room")
+	}
+
+	// Conditions may have changed since the request was made
+	if err := checkShareConditions(room, share.Type, initiator, target); err != nil {
+		return nil, err
+	}
+
+	share.CompletedAt = &now
+	if err := ss.completeShare(room, share, initiator, target); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// ForceShare makes two players card share immediately, without a request or refusal (Agent, Enforcer)
+// Forced shares ignore share-restricting conditions such as shy and coy.
+func (ss *ShareService) ForceShare(roomCode, forcedByID, playerAID, playerBID string) (*models.ShareRecord, error) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	room, err := ss.store.Get(roomCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.Status != models.RoomStatusInProgress || room.GameSession == nil {
+		return nil, errors.New("game is not in progress")
+	}
+
+	if playerAID == playerBID {
+		return nil, errors.New("cannot share with yourself")
+	}
+
+	playerA, playerB := findPlayer(room, playerAID), findPlayer(room, playerBID)
+	if playerA == nil || playerB == nil {
+		return nil, models.ErrPlayerNotFound
+	}
+
+	if playerA.CurrentRoom != playerB.CurrentRoom {
+		return nil, errors.New("can only share with players in your room")
+	}
+
+	now := time.Now()
+	share := &models.ShareRecord{
+		ID:          uuid.New().String(),
+		Type:        models.ShareTypeCard,
+		InitiatorID: playerAID,
+		TargetID:    playerBID,
+		RoundNumber: room.GameSession.CurrentRound,
+		Status:      models.ShareStatusPending,
+		ForcedBy:    forcedByID,
+		RequestedAt: now,
+		CompletedAt: &now,
+	}
+	room.GameSession.Shares = append(room.GameSession.Shares, share)
+
+	if err := ss.completeShare(room, share, playerA, playerB); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+// completeShare marks a share as accepted, tells both players what they saw and fires share powers
+func (ss *ShareService) completeShare(room *models.Room, share *models.ShareRecord, initiator, target *models.Player) error {
+	share.Status = models.ShareStatusAccepted
+
+	// Capture what each player reveals before effects change anything
+	initiatorView, targetView := sharedInfo(share, target), sharedInfo(share, initiator)
+
+	var affected [][2]*models.Player
+	if share.Type == models.ShareTypeCard {
+		affected = applyCardShareEffects(initiator, target)
+	}
+
+	// Zombies spread through every kind of share, card or color
+	zombie, converted := spreadZombie(initiator, target)
+
+	if err := ss.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Share completed: room=%s type=%s players=%s,%s forcedBy=%s",
+		room.Code, share.Type, initiator.ID, target.ID, share.ForcedBy)
+
+	// Each player privately learns what the other revealed
+	ss.sendToPlayer(room.Code, initiator.ID, websocket.MessageShareCompleted, initiatorView)
+	ss.sendToPlayer(room.Code, target.ID, websocket.MessageShareCompleted, targetView)
+
+	if converted != nil {
+		log.Printf("[INFO] Zombie spread: room=%s zombie=%s converted=%s", room.Code, zombie.ID, converted.ID)
+		ss.sendToPlayer(room.Code, converted.ID, websocket.MessageRoleAssigned, &websocket.RoleAssignedPayload{
+			Role:        converted.Role,
+			Team:        converted.Team,
+			CurrentRoom: converted.CurrentRoom,
+			Conditions:  append([]models.Condition{}, converted.Conditions...),
+			ConvertedBy: &websocket.LeaderInfo{ID: zombie.ID, Nickname: zombie.Nickname},
+		})
+	}
+
+	// Players whose conditions changed learn their new conditions privately
+	for _, pair := range affected {
+		holder, partner := pair[0], pair[1]
+		ss.sendToPlayer(room.Code, partner.ID, websocket.MessageConditionsChanged, &websocket.ConditionsChangedPayload{
+			Conditions: append([]models.Condition{}, partner.Conditions...),
+			Source:     &websocket.LeaderInfo{ID: holder.ID, Nickname: holder.Nickname},
+			Reason:     i18n.Msg("conditions.card_shared", roleDisplayName(holder.Role)).Render(partner.Locale),
+		})
+	}
+
+	if share.Type == models.ShareTypeCard {
+		ss.triggerCardSharePowers(room.Code, initiator, target)
+	}
+
+	// Card swaps happen last on any share, and only if a share power did not end the game
+	if room.Status == models.RoomStatusInProgress && swapsCards(initiator, target) {
+		return ss.swapCards(room, share, initiator, target)
+	}
+
+	return nil
+}
+
+// spreadZombie converts the other player when exactly one of the two is a zombie
+// Returns the zombie and the newly converted player, or nils if nobody was converted.
+func spreadZombie(a, b *models.Player) (zombie, converted *models.Player) {
+	switch {
+	case a.Team == models.TeamZombie && b.Team != models.TeamZombie:
+		zombie, converted = a, b
+	case b.Team == models.TeamZombie && a.Team != models.TeamZombie:
+		zombie, converted = b, a
+	default:
+		return nil, nil
+	}
+	converted.Team = models.TeamZombie
+	return zombie, converted
+}
+
+// swapsCards reports whether either player's card swaps on a card or color share
+func swapsCards(a, b *models.Player) bool {
+	for _, player := range []*models.Player{a, b} {
+		if player.Role != nil && player.Role.OnCardShare != nil && player.Role.OnCardShare.SwapCards {
+			return true
+		}
+	}
+	return false
+}
+
+// swapCards exchanges character cards between two players (Hot Potato, Leprechaun)
+// Under the cleanse rule both players drop their acquired conditions and keep
+// only the conditions printed on their new card.
+func (ss *ShareService) swapCards(room *models.Room, share *models.ShareRecord, a, b *models.Player) error {
+	now := time.Now()
+	for _, pair := range [][2]*models.Player{{a, b}, {b, a}} {
+		from, to := pair[0], pair[1]
+		if from.Role == nil {
+			continue
+		}
+		room.GameSession.CardTransfers = append(room.GameSession.CardTransfers, &models.CardTransfer{
+			RoleID:        from.Role.ID,
+			FromPlayerID:  from.ID,
+			ToPlayerID:    to.ID,
+			ShareID:       share.ID,
+			RoundNumber:   share.RoundNumber,
+			TransferredAt: now,
+		})
+	}
+
+	a.Role, b.Role = b.Role, a.Role
+	for _, player := range []*models.Player{a, b} {
+		// Zombies stay zombies whatever card they hold
+		if player.Team != models.TeamZombie && player.Role != nil {
+			player.Team = player.Role.Team
+		}
+		player.Cleanse()
+	}
+
+	if err := ss.store.Update(room); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Cards swapped: room=%s players=%s,%s share=%s", room.Code, a.ID, b.ID, share.ID)
+
+	// Each player privately learns their new card
+	for _, pair := range [][2]*models.Player{{a, b}, {b, a}} {
+		player, from := pair[0], pair[1]
+		ss.sendToPlayer(room.Code, player.ID, websocket.MessageRoleAssigned, &websocket.RoleAssignedPayload{
+			Role:        player.Role,
+			Team:        player.Team,
+			CurrentRoom: player.CurrentRoom,
+			Conditions:  append([]models.Condition{}, player.Conditions...),
+			SwappedWith: &websocket.LeaderInfo{ID: from.ID, Nickname: from.Nickname},
+		})
+	}
+
+	return nil
+}
+
+// applyCardShareEffects applies each player's onCardShare effect to the other
+// Returns (holder, partner) pairs for every partner whose conditions changed.
+func applyCardShareEffects(a, b *models.Player) [][2]*models.Player {
+	var affected [][2]*models.Player
+	for _, pair := range [][2]*models.Player{{a, b}, {b, a}} {
+		holder, partner := pair[0], pair[1]
+		if holder.Role == nil || holder.Role.OnCardShare == nil {
+			continue
+		}
+		if holder.Role.OnCardShare.Apply(partner) {
+			log.Printf("[INFO] Card share effect: role=%s player=%s conditions=%v", holder.Role.ID, partner.ID, partner.Conditions)
+			affected = append(affected, pair)
+		}
+	}
+	return affected
+}
+
+// checkShareConditions verifies that neither player's conditions forbid the share type
+func checkShareConditions(room *models.Room, shareType models.ShareType, players ...*models.Player) error {
+	for _, player := range players {
+		if player.HasCondition(models.ConditionShy) {
+			return &models.ShareError{Code: models.ShareRejectedShy, PlayerID: player.ID}
+		}
+
+		switch shareType {
+		case models.ShareTypeCard:
+			if player.HasCondition(models.ConditionCoy) {
+				return &models.ShareError{Code: models.ShareRejectedCoy, PlayerID: player.ID}
+			}
+			if player.HasCondition(models.ConditionParanoid) && hasCardShared(room, player.ID) {
+				return &models.ShareError{Code: models.ShareRejectedParanoid, PlayerID: player.ID}
+			}
+		case models.ShareTypeColor:
+			if player.HasCondition(models.ConditionSavvy) {
+				return &models.ShareError{Code: models.ShareRejectedSavvy, PlayerID: player.ID}
+			}
+		}
+	}
+	return nil
+}
+
+// hasCardShared reports whether the player has completed a card share this game
+func hasCardShared(room *models.Room, playerID string) bool {
+	for _, share := range room.GameSession.CompletedShares() {
+		if share.Type == models.ShareTypeCard && share.Involves(playerID) {
+			return true
+		}
+	}
+	return false
+}
+
+// triggerCardSharePowers applies powers that fire when two players card share
+func (ss *ShareService) triggerCardSharePowers(roomCode string, a, b *models.Player) {
+	for _, pair := range [][2]*models.Player{{a, b}, {b, a}} {
+		holder, partner := pair[0], pair[1]
+		if holder.Role == nil || partner.Role == nil {
+			continue
+		}
+
+		// BOOM: card sharing with the President detonates the holder's room
+		if holder.Role.Power == "BOOM" && partner.Role.ID == models.RolePresident.ID {
+			if ss.roundManager == nil {
+				log.Printf("[WARN] RoundManager not set, cannot end game for BOOM: room=%s", roomCode)
+				return
+			}
+
+			end := &models.EarlyEnd{
+				Reason:      models.EarlyEndBoom,
+				TriggeredBy: holder.ID,
+				RoleID:      holder.Role.ID,
+				Room:        holder.CurrentRoom,
+				EndedAt:     time.Now(),
+			}
+			if err := ss.roundManager.EndGameEarly(roomCode, end); err != nil {
+				log.Printf("[ERROR] Failed to end game for BOOM: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// sharedInfo builds what a share reveals about the partner
+// Spies show the opposite team's color during a color share.
+func sharedInfo(share *models.ShareRecord, partner *models.Player) *websocket.ShareCompletedPayload {
+	payload := &websocket.ShareCompletedPayload{
+		ShareID:   share.ID,
+		ShareType: share.Type,
+		Partner:   &websocket.LeaderInfo{ID: partner.ID, Nickname: partner.Nickname},
+		Team:      partner.Team,
+		Forced:    share.ForcedBy != "",
+	}
+
+	if share.Type == models.ShareTypeCard {
+		payload.Role = partner.Role
+		return payload
+	}
+
+	if partner.Role != nil && partner.Role.IsSpy {
+		switch partner.Team {
+		case models.TeamRed:
+			payload.Team = models.TeamBlue
+		case models.TeamBlue:
+			payload.Team = models.TeamRed
+		}
+	}
+
+	return payload
+}
+
+// sendToPlayer unicasts a message to a single player
+func (ss *ShareService) sendToPlayer(roomCode, playerID string, msgType websocket.MessageType, payload interface{}) {
+	if ss.hub == nil {
+		return
+	}
+
+	msg, err := websocket.NewMessage(msgType, payload)
+	if err != nil {
+		log.Printf("[ERROR] Failed to create %s message: %v", msgType, err)
+		return
+	}
+
+	data, _ := msg.Marshal()
+	ss.hub.SendToClient(roomCode, playerID, data)
+}
+
+// findPlayer returns the player with the given ID in the room, or nil if not found
+func findPlayer(room *models.Room, playerID string) *models.Player {
+	for _, player := range room.Players {
+		if player.ID == playerID {
+			return player
+		}
+	}
+	return nil
+}
@@ -0,0 +1,493 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+	"github.com/kalee/two-rooms-and-a-boom/internal/store"
+)
+
+// newShareTestRoom creates an in-progress room with three players
+// p1 and p2 are in the red room, p3 is in the blue room
+func newShareTestRoom(t *testing.T, roomStore *store.RoomStore) *models.Room {
+	t.Helper()
+	room := &models.Room{
+		Code:   "SHARE1",
+		Status: models.RoomStatusInProgress,
+		Players: []*models.Player{
+			{ID: "p1", Nickname: "플레이어1", Team: models.TeamRed, Role: &models.Role{ID: "RED_SPY", Team: models.TeamRed, IsSpy: true}, CurrentRoom: models.RedRoom},
+			{ID: "p2", Nickname: "플레이어2", Team: models.TeamBlue, Role: &models.Role{ID: "BLUE_TEAM", Team: models.TeamBlue}, CurrentRoom: models.RedRoom},
+			{ID: "p3", Nickname: "플레이어3", Team: models.TeamBlue, Role: &models.Role{ID: "PRESIDENT", Team: models.TeamBlue}, CurrentRoom: models.BlueRoom},
+		},
+		GameSession: &models.GameSession{CurrentRound: 1},
+	}
+	if err := roomStore.Create(room); err != nil {
+		t.Fatalf("Failed to create room: %v", err)
+	}
+	return room
+}
+
+func TestShareService_RequestShare(t *testing.T) {
+	tests := []struct {
+		name      string
+		initiator string
+		target    string
+		shareType models.ShareType
+		wantErr   bool
+	}{
+		{name: "card share in same room", initiator: "p1", target: "p2", shareType: models.ShareTypeCard},
+		{name: "color share in same room", initiator: "p2", target: "p1", shareType: models.ShareTypeColor},
+		{name: "reject different room", initiator: "p1", target: "p3", shareType: models.ShareTypeCard, wantErr: true},
+		{name: "reject self share", initiator: "p1", target: "p1", shareType: models.ShareTypeCard, wantErr: true},
+		{name: "reject unknown player", initiator: "p1", target: "nobody", shareType: models.ShareTypeCard, wantErr: true},
+		{name: "reject invalid type", initiator: "p1", target: "p2", shareType: "SECRET", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newShareTestRoom(t, roomStore)
+			shareService := NewShareService(roomStore, nil)
+
+			share, err := shareService.RequestShare(room.Code, tt.initiator, tt.target, tt.shareType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err == nil && share.Status != models.ShareStatusPending {
+				t.Errorf("Expected PENDING share, got %s", share.Status)
+			}
+		})
+	}
+
+	t.Run("reject duplicate pending request", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		if _, err := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := shareService.RequestShare(room.Code, "p2", "p1", models.ShareTypeColor); err == nil {
+			t.Error("Expected error for duplicate pending share")
+		}
+	})
+}
+
+func TestShareService_RespondToShare(t *testing.T) {
+	t.Run("accepted share is recorded in history", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p2", true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		updated, _ := roomStore.Get(room.Code)
+		completed := updated.GameSession.CompletedShares()
+		if len(completed) != 1 || completed[0].ID != share.ID {
+			t.Fatalf("Expected share in history, got %+v", completed)
+		}
+		if completed[0].CompletedAt == nil {
+			t.Error("Expected CompletedAt to be set")
+		}
+	})
+
+	t.Run("declined share is not in history", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p2", false); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		updated, _ := roomStore.Get(room.Code)
+		if len(updated.GameSession.CompletedShares()) != 0 {
+			t.Error("Expected no completed shares")
+		}
+		if share.Status != models.ShareStatusDeclined {
+			t.Errorf("Expected DECLINED, got %s", share.Status)
+		}
+	})
+
+	t.Run("only target can respond", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p1", true); err == nil {
+			t.Error("Expected error when initiator responds")
+		}
+	})
+
+	t.Run("cannot answer twice", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		shareService.RespondToShare(room.Code, share.ID, "p2", true)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p2", false); err == nil {
+			t.Error("Expected error for answered share")
+		}
+	})
+}
+
+func TestSharedInfo_SpyColor(t *testing.T) {
+	spy := &models.Player{ID: "spy", Team: models.TeamRed, Role: &models.Role{ID: "RED_SPY", Team: models.TeamRed, IsSpy: true}}
+
+	colorShare := &models.ShareRecord{Type: models.ShareTypeColor}
+	if got := sharedInfo(colorShare, spy); got.Team != models.TeamBlue || got.Role != nil {
+		t.Errorf("Expected spy to show BLUE without role on color share, got team=%s role=%v", got.Team, got.Role)
+	}
+
+	cardShare := &models.ShareRecord{Type: models.ShareTypeCard}
+	if got := sharedInfo(cardShare, spy); got.Team != models.TeamRed || got.Role == nil {
+		t.Errorf("Expected true team and role on card share, got team=%s role=%v", got.Team, got.Role)
+	}
+}
+
+func TestShareService_BoomPower(t *testing.T) {
+	newBoomRoom := func(t *testing.T, roomStore *store.RoomStore) *models.Room {
+		t.Helper()
+		room := &models.Room{
+			Code:   "BOOM01",
+			Status: models.RoomStatusInProgress,
+			Players: []*models.Player{
+				{ID: "drboom", Nickname: "닥터붐", Team: models.TeamRed, Role: &models.Role{ID: "DR_BOOM", Team: models.TeamRed, Power: "BOOM"}, CurrentRoom: models.BlueRoom},
+				{ID: "president", Nickname: "대통령", Team: models.TeamBlue, Role: &models.Role{ID: "PRESIDENT", Team: models.TeamBlue}, CurrentRoom: models.BlueRoom},
+				{ID: "bomber", Nickname: "폭파범", Team: models.TeamRed, Role: &models.Role{ID: "BOMBER", Team: models.TeamRed}, CurrentRoom: models.RedRoom},
+				{ID: "blue", Nickname: "블루", Team: models.TeamBlue, Role: &models.Role{ID: "BLUE_TEAM", Team: models.TeamBlue}, CurrentRoom: models.BlueRoom},
+			},
+			GameSession: &models.GameSession{
+				ID:           "session-boom",
+				CurrentRound: 1,
+				RoundState:   &models.RoundState{RoundNumber: 1, Status: models.RoundStatusActive},
+			},
+		}
+		if err := roomStore.Create(room); err != nil {
+			t.Fatalf("Failed to create room: %v", err)
+		}
+		return room
+	}
+
+	t.Run("card share with President ends the game", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newBoomRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+		shareService.SetRoundManager(NewRoundManager(nil, roomStore))
+
+		share, _ := shareService.RequestShare(room.Code, "president", "drboom", models.ShareTypeCard)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "drboom", true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		updated, _ := roomStore.Get(room.Code)
+		if updated.Status != models.RoomStatusRevealing {
+			t.Fatalf("Expected REVEALING, got %s", updated.Status)
+		}
+		outcome := updated.GameSession.Outcome
+		if outcome == nil || outcome.EarlyEnd == nil || outcome.EarlyEnd.Reason != models.EarlyEndBoom {
+			t.Fatalf("Expected BOOM early end, got %+v", outcome)
+		}
+		if outcome.WinningTeam != models.TeamRed || !outcome.PresidentDead {
+			t.Errorf("Expected Red win with dead President, got winner=%s presidentDead=%v", outcome.WinningTeam, outcome.PresidentDead)
+		}
+		if outcome.DetonationRoom != models.BlueRoom {
+			t.Errorf("Expected BLUE_ROOM detonation, got %s", outcome.DetonationRoom)
+		}
+		if updated.GameSession.RoundState.Status != models.RoundStatusComplete {
+			t.Errorf("Expected round to be complete, got %s", updated.GameSession.RoundState.Status)
+		}
+	})
+
+	t.Run("color share with President does not trigger", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newBoomRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+		shareService.SetRoundManager(NewRoundManager(nil, roomStore))
+
+		share, _ := shareService.RequestShare(room.Code, "drboom", "president", models.ShareTypeColor)
+		shareService.RespondToShare(room.Code, share.ID, "president", true)
+
+		updated, _ := roomStore.Get(room.Code)
+		if updated.Status != models.RoomStatusInProgress {
+			t.Errorf("Expected game to continue, got %s", updated.Status)
+		}
+	})
+
+	t.Run("card share with other player does not trigger", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newBoomRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+		shareService.SetRoundManager(NewRoundManager(nil, roomStore))
+
+		share, _ := shareService.RequestShare(room.Code, "drboom", "blue", models.ShareTypeCard)
+		shareService.RespondToShare(room.Code, share.ID, "blue", true)
+
+		updated, _ := roomStore.Get(room.Code)
+		if updated.Status != models.RoomStatusInProgress {
+			t.Errorf("Expected game to continue, got %s", updated.Status)
+		}
+	})
+}
+
+func TestShareService_ConditionRestrictions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions map[string][]models.Condition
+		priorCard  bool // p1 already completed a card share with p2
+		shareType  models.ShareType
+		wantCode   models.ShareRejection
+	}{
+		{name: "shy initiator cannot share", conditions: map[string][]models.Condition{"p1": {models.ConditionShy}}, shareType: models.ShareTypeColor, wantCode: models.ShareRejectedShy},
+		{name: "shy target cannot share", conditions: map[string][]models.Condition{"p2": {models.ConditionShy}}, shareType: models.ShareTypeColor, wantCode: models.ShareRejectedShy},
+		{name: "coy cannot card share", conditions: map[string][]models.Condition{"p1": {models.ConditionCoy}}, shareType: models.ShareTypeCard, wantCode: models.ShareRejectedCoy},
+		{name: "coy may color share", conditions: map[string][]models.Condition{"p1": {models.ConditionCoy}}, shareType: models.ShareTypeColor},
+		{name: "savvy cannot color share", conditions: map[string][]models.Condition{"p2": {models.ConditionSavvy}}, shareType: models.ShareTypeColor, wantCode: models.ShareRejectedSavvy},
+		{name: "savvy may card share", conditions: map[string][]models.Condition{"p2": {models.ConditionSavvy}}, shareType: models.ShareTypeCard},
+		{name: "paranoid first card share allowed", conditions: map[string][]models.Condition{"p1": {models.ConditionParanoid}}, shareType: models.ShareTypeCard},
+		{name: "paranoid second card share rejected", conditions: map[string][]models.Condition{"p1": {models.ConditionParanoid}}, priorCard: true, shareType: models.ShareTypeCard, wantCode: models.ShareRejectedParanoid},
+		{name: "paranoid may still color share", conditions: map[string][]models.Condition{"p1": {models.ConditionParanoid}}, priorCard: true, shareType: models.ShareTypeColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newShareTestRoom(t, roomStore)
+			for _, player := range room.Players {
+				player.Conditions = tt.conditions[player.ID]
+			}
+			if tt.priorCard {
+				room.GameSession.Shares = append(room.GameSession.Shares, &models.ShareRecord{
+					ID: "prior", Type: models.ShareTypeCard, InitiatorID: "p1", TargetID: "p2", Status: models.ShareStatusAccepted,
+				})
+			}
+			shareService := NewShareService(roomStore, nil)
+
+			_, err := shareService.RequestShare(room.Code, "p1", "p2", tt.shareType)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var shareErr *models.ShareError
+			if !errors.As(err, &shareErr) {
+				t.Fatalf("Expected ShareError, got %v", err)
+			}
+			if shareErr.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, shareErr.Code)
+			}
+		})
+	}
+
+	t.Run("foolish target cannot decline", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		findPlayer(room, "p2").Conditions = []models.Condition{models.ConditionFoolish}
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		_, err := shareService.RespondToShare(room.Code, share.ID, "p2", false)
+		var shareErr *models.ShareError
+		if !errors.As(err, &shareErr) || shareErr.Code != models.ShareRejectedFoolish {
+			t.Fatalf("Expected FOOLISH rejection, got %v", err)
+		}
+		if share.Status != models.ShareStatusPending {
+			t.Errorf("Expected share to stay PENDING, got %s", share.Status)
+		}
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p2", true); err != nil {
+			t.Errorf("Expected foolish target to accept, got %v", err)
+		}
+	})
+
+	t.Run("forced share ignores restrictions", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newShareTestRoom(t, roomStore)
+		findPlayer(room, "p2").Conditions = []models.Condition{models.ConditionShy}
+		shareService := NewShareService(roomStore, nil)
+
+		if _, err := shareService.ForceShare(room.Code, "p1", "p1", "p2"); err != nil {
+			t.Errorf("Expected forced share to succeed, got %v", err)
+		}
+	})
+}
+
+func TestShareService_CardShareEffects(t *testing.T) {
+	tests := []struct {
+		name           string
+		effect         *models.CardShareEffect
+		partnerBefore  []models.Condition
+		shareType      models.ShareType
+		wantConditions []models.Condition
+	}{
+		{name: "dealer gives foolish", effect: &models.CardShareEffect{GiveConditions: []models.Condition{models.ConditionFoolish}}, shareType: models.ShareTypeCard, wantConditions: []models.Condition{models.ConditionFoolish}},
+		{name: "mummy gives cursed", effect: &models.CardShareEffect{GiveConditions: []models.Condition{models.ConditionCursed}}, shareType: models.ShareTypeCard, wantConditions: []models.Condition{models.ConditionCursed}},
+		{name: "medic does nothing on color share", effect: &models.CardShareEffect{ClearConditions: true}, partnerBefore: []models.Condition{models.ConditionCoy, models.ConditionInLove}, shareType: models.ShareTypeColor, wantConditions: []models.Condition{models.ConditionCoy, models.ConditionInLove}},
+		{name: "medic clears on card share", effect: &models.CardShareEffect{ClearConditions: true}, partnerBefore: []models.Condition{models.ConditionParanoid, models.ConditionInLove}, shareType: models.ShareTypeCard, wantConditions: nil},
+		{name: "criminal does nothing on color share", effect: &models.CardShareEffect{GiveConditions: []models.Condition{models.ConditionShy}}, shareType: models.ShareTypeColor, wantConditions: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newShareTestRoom(t, roomStore)
+			findPlayer(room, "p1").Role.OnCardShare = tt.effect
+			partner := findPlayer(room, "p2")
+			partner.Conditions = tt.partnerBefore
+			shareService := NewShareService(roomStore, nil)
+
+			share, err := shareService.RequestShare(room.Code, "p2", "p1", tt.shareType)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := shareService.RespondToShare(room.Code, share.ID, "p1", true); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(partner.Conditions) != len(tt.wantConditions) {
+				t.Fatalf("Expected conditions %v, got %v", tt.wantConditions, partner.Conditions)
+			}
+			for _, c := range tt.wantConditions {
+				if !partner.HasCondition(c) {
+					t.Errorf("Expected condition %s, got %v", c, partner.Conditions)
+				}
+			}
+			if findPlayer(room, "p1").Conditions != nil {
+				t.Errorf("Expected effect holder to be unaffected, got %v", findPlayer(room, "p1").Conditions)
+			}
+		})
+	}
+}
+
+func TestShareService_CardSwap(t *testing.T) {
+	newSwapRoom := func(t *testing.T, roomStore *store.RoomStore) *models.Room {
+		t.Helper()
+		room := newShareTestRoom(t, roomStore)
+		potato := findPlayer(room, "p1")
+		potato.Team = models.TeamGrey
+		potato.Role = &models.Role{ID: "HOT_POTATO", Team: models.TeamGrey, OnCardShare: &models.CardShareEffect{SwapCards: true}}
+		return room
+	}
+
+	t.Run("card share swaps cards and records history", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newSwapRoom(t, roomStore)
+		partner := findPlayer(room, "p2")
+		partner.Conditions = []models.Condition{models.ConditionInLove}
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p2", "p1", models.ShareTypeCard)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p1", true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if partner.Role.ID != "HOT_POTATO" || partner.Team != models.TeamGrey {
+			t.Errorf("Expected p2 to hold HOT_POTATO as GREY, got %s as %s", partner.Role.ID, partner.Team)
+		}
+		if potato := findPlayer(room, "p1"); potato.Role.ID != "BLUE_TEAM" || potato.Team != models.TeamBlue {
+			t.Errorf("Expected p1 to hold BLUE_TEAM as BLUE, got %s as %s", potato.Role.ID, potato.Team)
+		}
+		if len(partner.Conditions) != 0 {
+			t.Errorf("Expected cleanse to drop acquired conditions, got %v", partner.Conditions)
+		}
+
+		history := room.GameSession.CardHistory("HOT_POTATO")
+		if len(history) != 1 || history[0].FromPlayerID != "p1" || history[0].ToPlayerID != "p2" {
+			t.Fatalf("Expected HOT_POTATO transfer p1 -> p2, got %+v", history)
+		}
+		if got := room.GameSession.OriginalHolder("HOT_POTATO", "p2"); got != "p1" {
+			t.Errorf("Expected original holder p1, got %s", got)
+		}
+	})
+
+	t.Run("new card keeps its printed conditions", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newSwapRoom(t, roomStore)
+		partner := findPlayer(room, "p2")
+		partner.Role = &models.Role{ID: "BLUE_PARANOID", Team: models.TeamBlue, Conditions: []models.Condition{models.ConditionParanoid}}
+		partner.Conditions = []models.Condition{models.ConditionParanoid}
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeCard)
+		shareService.RespondToShare(room.Code, share.ID, "p2", true)
+
+		if potato := findPlayer(room, "p1"); !potato.HasCondition(models.ConditionParanoid) {
+			t.Errorf("Expected p1 to gain PARANOID from the new card, got %v", potato.Conditions)
+		}
+		if partner.HasCondition(models.ConditionParanoid) {
+			t.Errorf("Expected p2 to lose PARANOID with the old card, got %v", partner.Conditions)
+		}
+	})
+
+	t.Run("color share swaps cards", func(t *testing.T) {
+		roomStore := store.NewRoomStore()
+		room := newSwapRoom(t, roomStore)
+		shareService := NewShareService(roomStore, nil)
+
+		share, _ := shareService.RequestShare(room.Code, "p1", "p2", models.ShareTypeColor)
+		if _, err := shareService.RespondToShare(room.Code, share.ID, "p2", true); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		if partner := findPlayer(room, "p2"); partner.Role.ID != "HOT_POTATO" || partner.Team != models.TeamGrey {
+			t.Errorf("Expected p2 to hold HOT_POTATO as GREY, got %s as %s", partner.Role.ID, partner.Team)
+		}
+		if potato := findPlayer(room, "p1"); potato.Role.ID != "BLUE_TEAM" {
+			t.Errorf("Expected p1 to hold BLUE_TEAM, got %s", potato.Role.ID)
+		}
+		if len(room.GameSession.CardHistory("HOT_POTATO")) != 1 {
+			t.Errorf("Expected the color share to record a transfer, got %+v", room.GameSession.CardTransfers)
+		}
+	})
+}
+
+func TestShareService_ZombieSpread(t *testing.T) {
+	tests := []struct {
+		name      string
+		shareType models.ShareType
+	}{
+		{name: "card share converts", shareType: models.ShareTypeCard},
+		{name: "color share converts", shareType: models.ShareTypeColor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roomStore := store.NewRoomStore()
+			room := newShareTestRoom(t, roomStore)
+			zombie := findPlayer(room, "p1")
+			zombie.Team = models.TeamZombie
+			zombie.Role = &models.Role{ID: "ZOMBIE", Team: models.TeamZombie}
+			shareService := NewShareService(roomStore, nil)
+
+			share, _ := shareService.RequestShare(room.Code, "p2", "p1", tt.shareType)
+			if _, err := shareService.RespondToShare(room.Code, share.ID, "p1", true); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			victim := findPlayer(room, "p2")
+			if victim.Team != models.TeamZombie {
+				t.Errorf("Expected p2 to become a zombie, got %s", victim.Team)
+			}
+			if victim.Role.ID != "BLUE_TEAM" {
+				t.Errorf("Expected p2 to keep their card, got %s", victim.Role.ID)
+			}
+		})
+	}
+
+	t.Run("partner sees original team", func(t *testing.T) {
+		blue := &models.Player{ID: "p2", Team: models.TeamBlue, Role: &models.Role{ID: "BLUE_TEAM", Team: models.TeamBlue}}
+		view := sharedInfo(&models.ShareRecord{Type: models.ShareTypeColor}, blue)
+		spreadZombie(&models.Player{ID: "z", Team: models.TeamZombie}, blue)
+		if view.Team != models.TeamBlue {
+			t.Errorf("Expected the view captured before the spread to show BLUE, got %s", view.Team)
+		}
+	})
+}
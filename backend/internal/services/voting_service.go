@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 	"github.com/kalee/two-rooms-and-a-boom/internal/store"
 	"github.com/kalee/two-rooms-and-a-boom/internal/websocket"
@@ -49,6 +50,10 @@ func (vs *VotingService) StartVote(roomCode, initiatorID, targetLeaderID string,
 		return "", errors.New("no active round")
 	}
 
+	if room.Status != models.RoomStatusInProgress {
+		return "", errors.New("game is not in progress")
+	}
+
 	roundState := room.GameSession.RoundState
 
 	// Validation: Not in SELECTING phase
@@ -670,6 +675,11 @@ func (vs *VotingService) StartElectionVote(roomCode string, roomColor models.Roo
 		return "", errors.New("no active round")
 	}
 
+	// The game may have ended while the removal result was on screen
+	if room.Status != models.RoomStatusInProgress {
+		return "", errors.New("game is not in progress")
+	}
+
 	// Get eligible candidates (all players in room except removed leader)
 	var candidates []*models.Player
 	var removedLeader *models.Player
@@ -703,7 +713,7 @@ func (vs *VotingService) StartElectionVote(roomCode string, roomColor models.Roo
 		GameSessionID:  room.GameSession.ID,
 		RoomColor:      roomColor,
 		InitiatorID:    "",  // System-initiated
-		InitiatorName:  "시스템", // System
+		InitiatorName:  i18n.Msg("system.name").Render(i18n.DefaultLocale),
 		Candidates:     candidateIDs,
 		StartedAt:      now,
 		ExpiresAt:      expiresAt,
@@ -738,27 +748,21 @@ func (vs *VotingService) StartElectionVote(roomCode string, roomColor models.Roo
 				return ""
 			}(),
 		},
-		Initiator: &websocket.LeaderInfo{
-			ID:       "",
-			Nickname: "시스템",
-		},
 		Candidates:     candidateIDs,     // Include candidate IDs for election
 		TotalVoters:    len(candidates),
 		TimeoutSeconds: 30,
 		StartedAt:      now.Format(time.RFC3339),
 	}
 
-	msg, err := websocket.NewMessage(websocket.MessageVoteSessionStarted, payload)
-	if err != nil {
-		return "", err
-	}
-
-	data, _ := msg.Marshal()
-
-	// Broadcast to players in the specific room color
+	// Broadcast to players in the specific room color; the system initiates the vote,
+	// and is named in each player's language
 	playerIDs, err := vs.getPlayerIDsInRoomColor(roomCode, roomColor)
 	if err == nil {
-		vs.hub.BroadcastToRoomColor(roomCode, playerIDs, data)
+		vs.hub.BroadcastToRoomColorLocalized(roomCode, playerIDs, func(locale string) (*websocket.Message, error) {
+			localized := *payload
+			localized.Initiator = &websocket.LeaderInfo{ID: "", Nickname: i18n.Msg("system.name").Render(locale)}
+			return websocket.NewMessage(websocket.MessageVoteSessionStarted, &localized)
+		})
 	}
 
 	// Start timeout goroutine
@@ -771,3 +775,50 @@ func (vs *VotingService) StartElectionVote(roomCode string, roomColor models.Roo
 func getRoomVoteKey(roomCode string, roomColor models.RoomColor) string {
 	return roomCode + ":" + string(roomColor)
 }
+
+// CancelRoomVotes cancels every active vote in a room (used when the game ends early)
+// Cancelled sessions are ignored by CompleteVote and the timeout handler.
+func (vs *VotingService) CancelRoomVotes(roomCode string) {
+	vs.mu.Lock()
+	var cancelled []*models.VoteSession
+	for _, roomColor := range []models.RoomColor{models.RedRoom, models.BlueRoom} {
+		roomKey := getRoomVoteKey(roomCode, roomColor)
+		voteID, exists := vs.roomVotes[roomKey]
+		if !exists {
+			continue
+		}
+		delete(vs.roomVotes, roomKey)
+
+		session, exists := vs.sessions[voteID]
+		if !exists || session.Status != models.VoteStatusActive {
+			continue
+		}
+		session.Status = models.VoteStatusCancelled
+		cancelled = append(cancelled, session)
+	}
+	vs.mu.Unlock()
+
+	for _, session := range cancelled {
+		log.Printf("[INFO] Vote cancelled: voteID=%s room=%s", session.VoteID, roomCode)
+
+		if vs.hub == nil {
+			continue
+		}
+
+		payload := &websocket.VoteCompletedPayload{
+			VoteID: session.VoteID,
+			Result: models.VoteResultCancelled,
+			Reason: "GAME_ENDED",
+		}
+		msg, err := websocket.NewMessage(websocket.MessageVoteCompleted, payload)
+		if err != nil {
+			continue
+		}
+		data, _ := msg.Marshal()
+
+		playerIDs, err := vs.getPlayerIDsInRoomColor(roomCode, session.RoomColor)
+		if err == nil {
+			vs.hub.BroadcastToRoomColor(roomCode, playerIDs, data)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"log"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/config"
+	"github.com/kalee/two-rooms-and-a-boom/internal/expr"
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
+	"github.com/kalee/two-rooms-and-a-boom/internal/models"
+)
+
+// expressionWinEvaluator judges a player by a winCondition expression from the role config
+// The expression was checked when the config loaded; a failure here is logged and counts as a loss.
+func expressionWinEvaluator(src string) winEvaluator {
+	return func(ctx *outcomeContext, player *models.Player) (bool, i18n.Message) {
+		program, err := config.CompileWinExpression(src)
+		if err != nil {
+			log.Printf("[WARN] Invalid winCondition expression %q: %v", src, err)
+			return false, i18n.Msg("outcome.expression.invalid")
+		}
+
+		won, err := program.EvalBool(ctx.expressionEnv(player))
+		if err != nil {
+			log.Printf("[WARN] Failed to evaluate winCondition expression %q for player %s: %v", src, player.ID, err)
+			return false, i18n.Msg("outcome.expression.failed")
+		}
+		if won {
+			return true, i18n.Msg("outcome.expression.met")
+		}
+		return false, i18n.Msg("outcome.expression.not_met")
+	}
+}
+
+// expressionEnv exposes the end-of-game state to a winCondition expression judging the player
+// Every name in config.WinExpressionSymbols has an entry here.
+func (c *outcomeContext) expressionEnv(player *models.Player) *expr.Env {
+	presidentDead := false
+	if president := c.findByRole(models.RolePresident.ID); president != nil {
+		presidentDead = c.dead[president.ID]
+	}
+
+	return &expr.Env{
+		Vars: map[string]expr.Value{
+			"me":            c.playerObject(player),
+			"winner":        string(c.winningTeam),
+			"presidentDead": presidentDead,
+		},
+		Funcs: map[string]expr.Func{
+			"role": c.roleFunc(func(other *models.Player) expr.Value {
+				if other == nil {
+					return nil
+				}
+				return c.playerObject(other)
+			}),
+			"inPlay": c.roleFunc(func(other *models.Player) expr.Value {
+				return other != nil
+			}),
+			"sameRoom": c.roleFunc(func(other *models.Player) expr.Value {
+				return other != nil && other.CurrentRoom == player.CurrentRoom
+			}),
+			"cardShared": c.roleFunc(func(other *models.Player) expr.Value {
+				return other != nil && c.shared(player.ID, other.ID, models.ShareTypeCard)
+			}),
+			"colorShared": c.roleFunc(func(other *models.Player) expr.Value {
+				return other != nil && c.shared(player.ID, other.ID, models.ShareTypeColor)
+			}),
+			"cardShares": func([]expr.Value) (expr.Value, error) {
+				count := 0
+				for _, share := range c.shares {
+					if share.Type == models.ShareTypeCard && share.Involves(player.ID) {
+						count++
+					}
+				}
+				return count, nil
+			},
+			"ledRoom": c.roomFunc(func(roomColor models.RoomColor) expr.Value {
+				return c.session != nil && c.session.RoomsLedBy(player.ID)[roomColor]
+			}),
+			"timesLed": func([]expr.Value) (expr.Value, error) {
+				count := 0
+				if c.session != nil {
+					for _, change := range c.session.LeaderHistory {
+						if change.LeaderID == player.ID {
+							count++
+						}
+					}
+				}
+				return count, nil
+			},
+			"hostageRounds": func([]expr.Value) (expr.Value, error) {
+				rounds := make(map[int]bool)
+				for _, move := range c.roomHistory(player.ID) {
+					if move.Reason == models.MoveReasonHostage {
+						rounds[move.RoundNumber] = true
+					}
+				}
+				return len(rounds), nil
+			},
+			"leftStartingRoom": func([]expr.Value) (expr.Value, error) {
+				for _, move := range c.roomHistory(player.ID) {
+					if move.Reason != models.MoveReasonStart {
+						return true, nil
+					}
+				}
+				return false, nil
+			},
+			"countInRoom": c.roomFunc(func(roomColor models.RoomColor) expr.Value {
+				count := 0
+				for _, other := range c.players {
+					if other.CurrentRoom == roomColor {
+						count++
+					}
+				}
+				return count
+			}),
+		},
+	}
+}
+
+// playerObject describes a player to an expression
+func (c *outcomeContext) playerObject(player *models.Player) map[string]expr.Value {
+	roleID := ""
+	if player.Role != nil {
+		roleID = player.Role.ID
+	}
+	var leader models.RoomColor
+	if c.session != nil {
+		leader = c.session.LeaderRoomAtEnd(player.ID)
+	}
+
+	return map[string]expr.Value{
+		"id":         player.ID,
+		"nickname":   player.Nickname,
+		"role":       roleID,
+		"team":       player.Team,
+		"room":       player.CurrentRoom,
+		"dead":       c.dead[player.ID],
+		"leader":     leader,
+		"conditions": player.Conditions,
+	}
+}
+
+// shared reports whether the two players completed a share of the given type with each other
+func (c *outcomeContext) shared(playerID, otherID string, shareType models.ShareType) bool {
+	for _, share := range c.shares {
+		if share.Type == shareType && share.Involves(playerID) && share.PartnerOf(playerID) == otherID {
+			return true
+		}
+	}
+	return false
+}
+
+// roleFunc adapts a function of a role's holder to an expression function taking a role ID
+func (c *outcomeContext) roleFunc(fn func(holder *models.Player) expr.Value) expr.Func {
+	return func(args []expr.Value) (expr.Value, error) {
+		roleID, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("role ID must be text")
+		}
+		return fn(c.findByRole(roleID)), nil
+	}
+}
+
+// roomFunc adapts a function of a room to an expression function taking a room name
+func (c *outcomeContext) roomFunc(fn func(roomColor models.RoomColor) expr.Value) expr.Func {
+	return func(args []expr.Value) (expr.Value, error) {
+		room, ok := args[0].(string)
+		if !ok {
+			return nil, errors.New("room must be text")
+		}
+		return fn(models.RoomColor(room)), nil
+	}
+}
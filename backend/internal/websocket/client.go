@@ -34,6 +34,9 @@ type Client struct {
 	// Player ID
 	playerID string
 
+	// Locale server text is sent in (e.g. "ko", "en", "ja"); "" reads as i18n.DefaultLocale
+	locale string
+
 	// Buffered channel of outbound messages
 	send chan []byte
 }
@@ -54,6 +57,11 @@ func (c *Client) SetPlayerID(playerID string) {
 	c.playerID = playerID
 }
 
+// SetLocale sets the locale messages built per locale are sent to this client in
+func (c *Client) SetLocale(locale string) {
+	c.locale = locale
+}
+
 // Send sends a message to this client
 // Send sends a message to this client
 // Send sends a message to this client
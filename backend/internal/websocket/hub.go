@@ -4,6 +4,8 @@ import (
 	"log"
 	"sync"
 	"time"
+
+	"github.com/kalee/two-rooms-and-a-boom/internal/i18n"
 )
 
 // Hub maintains active WebSocket connections and broadcasts messages
@@ -166,6 +168,55 @@ func (h *Hub) SendToClient(roomCode, playerID string, message []byte) {
 	}
 }
 
+// BroadcastLocalized sends a message to all clients in a room, each in the client's locale
+// build is called once for every locale the room's clients read.
+func (h *Hub) BroadcastLocalized(roomCode string, build func(locale string) (*Message, error)) {
+	h.sendLocalized(roomCode, nil, build)
+}
+
+// BroadcastToRoomColorLocalized is BroadcastToRoomColor for a message built per locale
+func (h *Hub) BroadcastToRoomColorLocalized(roomCode string, playerIDs []string, build func(locale string) (*Message, error)) {
+	targetPlayers := make(map[string]bool)
+	for _, id := range playerIDs {
+		targetPlayers[id] = true
+	}
+	h.sendLocalized(roomCode, targetPlayers, build)
+}
+
+// sendLocalized sends a message built per locale to the room's clients, or only to the
+// target players if targetPlayers is not nil
+func (h *Hub) sendLocalized(roomCode string, targetPlayers map[string]bool, build func(locale string) (*Message, error)) {
+	// Create a copy of clients while holding the lock to avoid concurrent map iteration
+	h.mu.RLock()
+	roomClients := h.rooms[roomCode]
+	clientsCopy := make([]*Client, 0, len(roomClients))
+	for client := range roomClients {
+		clientsCopy = append(clientsCopy, client)
+	}
+	h.mu.RUnlock()
+
+	built := make(map[string][]byte) // locale -> marshalled message, nil if it failed
+	for _, client := range clientsCopy {
+		if targetPlayers != nil && !targetPlayers[client.playerID] {
+			continue
+		}
+		data, ok := built[client.locale]
+		if !ok {
+			msg, err := build(client.locale)
+			if err == nil {
+				data, err = msg.Marshal()
+			}
+			if err != nil {
+				log.Printf("[ERROR] Failed to build message for locale %q in room %s: %v", client.locale, roomCode, err)
+			}
+			built[client.locale] = data
+		}
+		if data != nil {
+			client.Send(data)
+		}
+	}
+}
+
 // cleanupDisconnected removes players who haven't reconnected within 30 seconds
 func (h *Hub) cleanupDisconnected() {
 	ticker := time.NewTicker(5 * time.Second)
@@ -267,12 +318,9 @@ func (h *Hub) BroadcastOwnerChanged(roomCode string, payload *OwnerChangedPayloa
 	return nil
 }
 
-// BroadcastRoomClosed broadcasts ROOM_CLOSED event to all players in the room
-func (h *Hub) BroadcastRoomClosed(roomCode string) error {
-	payload := &RoomClosedPayload{
-		Reason: "방장이 나갔습니다",
-	}
-	msg, err := NewMessage(MessageRoomClosed, payload)
+// BroadcastRoleSetChanged broadcasts ROLE_SET_CHANGED event to all players in the room
+func (h *Hub) BroadcastRoleSetChanged(roomCode string, payload *RoleSetChangedPayload) error {
+	msg, err := NewMessage(MessageRoleSetChanged, payload)
 	if err != nil {
 		return err
 	}
@@ -286,6 +334,16 @@ func (h *Hub) BroadcastRoomClosed(roomCode string) error {
 	return nil
 }
 
+// BroadcastRoomClosed broadcasts ROOM_CLOSED event to all players in the room
+func (h *Hub) BroadcastRoomClosed(roomCode string) error {
+	h.BroadcastLocalized(roomCode, func(locale string) (*Message, error) {
+		return NewMessage(MessageRoomClosed, &RoomClosedPayload{
+			Reason: i18n.Msg("room.closed.owner_left").Render(locale),
+		})
+	})
+	return nil
+}
+
 // T074: Implement GAME_STARTED broadcast
 func (h *Hub) BroadcastGameStarted(roomCode string, payload interface{}) error {
 	msg, err := NewMessage(MessageGameStarted, payload)
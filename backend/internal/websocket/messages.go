@@ -2,6 +2,7 @@ package websocket
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/kalee/two-rooms-and-a-boom/internal/models"
 )
@@ -15,6 +16,7 @@ const (
 	MessagePlayerDisconnected MessageType = "PLAYER_DISCONNECTED"
 	MessageNicknameChanged    MessageType = "NICKNAME_CHANGED"
 	MessageOwnerChanged       MessageType = "OWNER_CHANGED"
+	MessageRoleSetChanged     MessageType = "ROLE_SET_CHANGED"
 	MessageRoomClosed         MessageType = "ROOM_CLOSED"
 	MessageGameStarted        MessageType = "GAME_STARTED"
 	MessageRoleAssigned       MessageType = "ROLE_ASSIGNED"
@@ -45,6 +47,19 @@ const (
 	MessageLeaderAnnouncedHostages   MessageType = "LEADER_ANNOUNCED_HOSTAGES"
 	MessageExchangeReady             MessageType = "EXCHANGE_READY"
 	MessageExchangeComplete          MessageType = "EXCHANGE_COMPLETE"
+
+	// Card/color share events (unicast)
+	MessageShareRequested MessageType = "SHARE_REQUESTED"
+	MessageShareDeclined  MessageType = "SHARE_DECLINED"
+	MessageShareCompleted MessageType = "SHARE_COMPLETED"
+
+	// Power and condition events (unicast)
+	MessageConditionsChanged MessageType = "CONDITIONS_CHANGED"
+
+	// Final actions phase events (after round 3)
+	MessageFinalActionsStarted MessageType = "FINAL_ACTIONS_STARTED"
+	MessageFinalActionRequired MessageType = "FINAL_ACTION_REQUIRED" // unicast
+	MessageFinalActionRecorded MessageType = "FINAL_ACTION_RECORDED" // unicast
 )
 
 // Message represents a WebSocket message
@@ -79,6 +94,15 @@ type OwnerChangedPayload struct {
 	NewOwner *models.Player `json:"newOwner"`
 }
 
+// RoleSetChangedPayload for ROLE_SET_CHANGED event
+// Sent when the owner changes the room's roles in the lobby.
+type RoleSetChangedPayload struct {
+	RoleConfigID       string          `json:"roleConfigId"`
+	RoleConfigRevision int             `json:"roleConfigRevision,omitempty"`
+	SelectedRoles      map[string]int  `json:"selectedRoles,omitempty"`
+	CustomRoleConfig   json.RawMessage `json:"customRoleConfig,omitempty"` // Resolved inline role set, if any
+}
+
 // RoomClosedPayload for ROOM_CLOSED event
 type RoomClosedPayload struct {
 	Reason string `json:"reason"`
@@ -94,6 +118,9 @@ type RoleAssignedPayload struct {
 	Role        *models.Role      `json:"role"`
 	Team        models.TeamColor  `json:"team"`
 	CurrentRoom models.RoomColor  `json:"currentRoom"`
+	Conditions  []models.Condition `json:"conditions,omitempty"`  // Conditions after a card swap cleanse
+	SwappedWith *LeaderInfo       `json:"swappedWith,omitempty"` // Player the card came from (card swaps only)
+	ConvertedBy *LeaderInfo       `json:"convertedBy,omitempty"` // Zombie who converted the player
 }
 
 // GameResetPayload for GAME_RESET event
@@ -145,7 +172,8 @@ type LeaderReadyPayload struct {
 
 // GameRevealingPayload for GAME_REVEALING event
 type GameRevealingPayload struct {
-	Message string `json:"message"`
+	Message string              `json:"message"`
+	Outcome *models.GameOutcome `json:"outcome,omitempty"`
 }
 
 // LeadershipChangedPayload for LEADERSHIP_CHANGED event
@@ -224,6 +252,56 @@ type ExchangeCompletePayload struct {
 	NextRound   int               `json:"nextRound,omitempty"`
 }
 
+// ShareRequestedPayload for SHARE_REQUESTED event (unicast to target)
+type ShareRequestedPayload struct {
+	ShareID   string           `json:"shareId"`
+	ShareType models.ShareType `json:"shareType"`
+	Initiator *LeaderInfo      `json:"initiator"`
+}
+
+// ShareDeclinedPayload for SHARE_DECLINED event (unicast to initiator)
+type ShareDeclinedPayload struct {
+	ShareID string      `json:"shareId"`
+	Target  *LeaderInfo `json:"target"`
+}
+
+// ShareCompletedPayload for SHARE_COMPLETED event (unicast to each participant)
+// Role is only set for card shares; Team is the color the partner revealed.
+type ShareCompletedPayload struct {
+	ShareID   string           `json:"shareId"`
+	ShareType models.ShareType `json:"shareType"`
+	Partner   *LeaderInfo      `json:"partner"`
+	Role      *models.Role     `json:"role,omitempty"`
+	Team      models.TeamColor `json:"team"`
+	Forced    bool             `json:"forced,omitempty"` // Share was forced by an Agent or Enforcer
+}
+
+// ConditionsChangedPayload for CONDITIONS_CHANGED event (unicast to affected player)
+type ConditionsChangedPayload struct {
+	Conditions []models.Condition `json:"conditions"`       // Conditions held after the change
+	Source     *LeaderInfo        `json:"source,omitempty"` // Player who caused the change
+	Reason     string             `json:"reason"`
+}
+
+// FinalActionsStartedPayload for FINAL_ACTIONS_STARTED event
+type FinalActionsStartedPayload struct {
+	Deadline        time.Time `json:"deadline"`
+	DurationSeconds int       `json:"durationSeconds"`
+}
+
+// FinalActionRequiredPayload for FINAL_ACTION_REQUIRED event (unicast to acting players)
+type FinalActionRequiredPayload struct {
+	ActionType models.FinalActionType `json:"actionType"` // SHOOT or PREDICT
+	Deadline   time.Time              `json:"deadline"`
+}
+
+// FinalActionRecordedPayload for FINAL_ACTION_RECORDED event (unicast confirmation)
+type FinalActionRecordedPayload struct {
+	ActionType models.FinalActionType `json:"actionType"`
+	TargetID   string                 `json:"targetId,omitempty"`
+	Prediction models.TeamColor       `json:"prediction,omitempty"`
+}
+
 // NewMessage creates a new WebSocket message
 func NewMessage(msgType MessageType, payload interface{}) (*Message, error) {
 	data, err := json.Marshal(payload)
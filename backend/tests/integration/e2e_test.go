@@ -29,11 +29,11 @@ func TestE2E_CompleteGameFlow(t *testing.T) {
 	// Initialize services
 	roomService := services.NewRoomService(roomStore)
 	playerService := services.NewPlayerService(roomStore, hub)
-	gameService := services.NewGameService(roomStore)
+	gameService := services.NewGameService(roomStore, nil)
 	gameService.SetHub(hub)
 
 	// Initialize handlers
-	roomHandler := handlers.NewRoomHandler(roomService)
+	roomHandler := handlers.NewRoomHandler(roomService, nil)
 	playerHandler := handlers.NewPlayerHandler(playerService)
 	gameHandler := handlers.NewGameHandler(gameService)
 
@@ -38,7 +38,7 @@ func TestE2E_UserStory1_CompleteFlow(t *testing.T) {
 	playerService := services.NewPlayerService(roomStore, hub)
 
 	// Initialize handlers
-	roomHandler := handlers.NewRoomHandler(roomService)
+	roomHandler := handlers.NewRoomHandler(roomService, nil)
 	playerHandler := handlers.NewPlayerHandler(playerService)
 	wsHandler := handlers.NewWebSocketHandler(hub, roomService, playerService)
 
@@ -374,7 +374,7 @@ func TestE2E_UserStory1_WebSocketRealTime(t *testing.T) {
 	roomService := services.NewRoomService(roomStore)
 	playerService := services.NewPlayerService(roomStore, hub)
 
-	roomHandler := handlers.NewRoomHandler(roomService)
+	roomHandler := handlers.NewRoomHandler(roomService, nil)
 	playerHandler := handlers.NewPlayerHandler(playerService)
 	wsHandler := handlers.NewWebSocketHandler(hub, roomService, playerService)
 
@@ -452,7 +452,7 @@ func TestE2E_UserStory1_RoomCapacity(t *testing.T) {
 	roomService := services.NewRoomService(roomStore)
 	playerService := services.NewPlayerService(roomStore, hub)
 
-	roomHandler := handlers.NewRoomHandler(roomService)
+	roomHandler := handlers.NewRoomHandler(roomService, nil)
 	playerHandler := handlers.NewPlayerHandler(playerService)
 
 	v1 := router.Group("/api/v1")
@@ -497,11 +497,14 @@ func TestE2E_UserStory1_RoomCapacity(t *testing.T) {
 	}
 
 	// Try to add 7th player (should fail)
-	resp, _ = http.Post(
+	resp, err := http.Post(
 		server.URL+"/api/v1/rooms/"+roomCode+"/players",
 		"application/json",
 		nil,
 	)
+	if err != nil {
+		t.Fatalf("Failed to add 7th player: %v", err)
+	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusConflict {
@@ -19,7 +19,7 @@ func setupGameTestRouter() (*gin.Engine, *store.RoomStore) {
 	router := gin.Default()
 	roomStore := store.NewRoomStore()
 
-	gameService := services.NewGameService(roomStore)
+	gameService := services.NewGameService(roomStore, nil)
 
 	gameHandler := handlers.NewGameHandler(gameService)
 
@@ -23,7 +23,7 @@ func setupTestRouter() (*gin.Engine, *store.RoomStore) {
 	roomService := services.NewRoomService(roomStore)
 	playerService := services.NewPlayerService(roomStore, nil)
 
-	roomHandler := handlers.NewRoomHandler(roomService)
+	roomHandler := handlers.NewRoomHandler(roomService, nil)
 	playerHandler := handlers.NewPlayerHandler(playerService)
 
 	v1 := router.Group("/api/v1")
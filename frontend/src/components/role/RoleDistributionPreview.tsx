@@ -0,0 +1,110 @@
+import { useEffect, useState } from 'react';
+import { previewRoleDistribution } from '../../services/api';
+import type { RoleDistribution, TeamDistribution } from '../../services/api';
+
+interface RoleDistributionPreviewProps {
+  roleConfigId: string;
+  revision?: number;
+  playerCount: number;
+  selectedRoles?: Record<string, number>;
+}
+
+const TEAM_LABELS: Record<TeamDistribution['team'], string> = {
+  RED: '레드 팀',
+  BLUE: '블루 팀',
+  GREY: '그레이',
+  ZOMBIE: '좀비',
+};
+
+const TEAM_COLORS: Record<TeamDistribution['team'], string> = {
+  RED: '#dc3545',
+  BLUE: '#007bff',
+  GREY: '#6c757d',
+  ZOMBIE: '#28a745',
+};
+
+// Shows the cards each team will be dealt and any problem that would stop the game from starting.
+// Refreshes whenever the player count or role selection changes.
+export function RoleDistributionPreview({
+  roleConfigId,
+  revision,
+  playerCount,
+  selectedRoles,
+}: RoleDistributionPreviewProps) {
+  const [distribution, setDistribution] = useState<RoleDistribution | null>(null);
+  const [error, setError] = useState('');
+  const selectionKey = JSON.stringify(selectedRoles ?? {});
+
+  useEffect(() => {
+    if (playerCount < 1) return;
+
+    let cancelled = false;
+    previewRoleDistribution(roleConfigId, playerCount, selectedRoles, revision)
+      .then((result) => {
+        if (!cancelled) {
+          setDistribution(result);
+          setError('');
+        }
+      })
+      .catch((err) => {
+        console.error('Failed to preview role distribution:', err);
+        if (!cancelled) {
+          setError('역할 배분을 미리 볼 수 없습니다');
+        }
+      });
+
+    return () => {
+      cancelled = true;
+    };
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [roleConfigId, revision, playerCount, selectionKey]);
+
+  if (error) {
+    return <div style={{ color: '#cc0000', fontSize: '0.875rem' }}>{error}</div>;
+  }
+  if (!distribution) {
+    return null;
+  }
+
+  return (
+    <div
+      style={{
+        padding: '1rem',
+        border: '1px solid var(--border-color, #ddd)',
+        borderRadius: '8px',
+        fontSize: '0.875rem',
+      }}
+    >
+      <div style={{ fontWeight: 'bold', marginBottom: '0.5rem' }}>
+        역할 배분 미리보기 ({distribution.playerCount}명
+        {distribution.buried ? ', 카드 1장 묻힘' : ''})
+      </div>
+
+      {distribution.teams
+        .filter((team) => team.size > 0)
+        .map((team) => (
+          <div key={team.team} style={{ marginBottom: '0.25rem' }}>
+            <span style={{ color: TEAM_COLORS[team.team], fontWeight: 'bold' }}>
+              {TEAM_LABELS[team.team]} {team.size}장
+            </span>
+            {': '}
+            {[
+              ...team.roles.map((role) => `${role.nameKo || role.name} ×${role.count}`),
+              ...(team.fill > 0 ? [`${team.fillRoleId} ×${team.fill}`] : []),
+            ].join(', ')}
+          </div>
+        ))}
+
+      {distribution.errors.map((message) => (
+        <div key={message} style={{ color: '#cc0000', marginTop: '0.25rem' }}>
+          ⛔ {message}
+        </div>
+      ))}
+      {distribution.warnings.map((message) => (
+        <div key={message} style={{ color: '#b8860b', marginTop: '0.25rem' }}>
+          ⚠️ {message}
+        </div>
+      ))}
+    </div>
+  );
+}
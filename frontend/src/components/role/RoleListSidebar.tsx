@@ -1,6 +1,6 @@
 import { useState, useEffect } from 'react';
 import { getRoleConfig } from '../../services/api';
-import type { RoleConfig, RoleDefinition } from '../../types/roleConfig';
+import type { RoleConfig, RoleCount, RoleDefinition } from '../../types/roleConfig';
 
 interface RoleListSidebarProps {
   roleConfigId?: string;
@@ -38,10 +38,14 @@ function getTeamNameKo(team: 'RED' | 'BLUE' | 'GREY'): string {
 }
 
 // Helper function to format role count
-function formatRoleCount(count: number | Record<string, number>): string {
+function formatRoleCount(count: RoleCount): string {
   if (typeof count === 'number') {
     return `${count}명`;
   }
+  // Formulas depend on the player count
+  if (typeof count === 'string' || 'formula' in count) {
+    return '가변';
+  }
   // For dynamic counts based on player count
   const entries = Object.entries(count);
   if (entries.length === 0) return '0명';
@@ -7,6 +7,7 @@ import { LoadingSpinner } from '../components/common/LoadingSpinner';
 import { RoleCard } from '../components/role/RoleCard';
 import { RoomPlayerList } from '../components/room/RoomPlayerList';
 import { RoleListSidebar } from '../components/role/RoleListSidebar';
+import { RoleDistributionPreview } from '../components/role/RoleDistributionPreview';
 import { RoundTimer } from '../components/game/RoundTimer';
 import { RoundTimerPanel } from '../components/game/RoundTimerPanel';
 import { LeaderTransferModal } from '../components/game/LeaderTransferModal';
@@ -30,6 +31,7 @@ import {
   APIError,
   API_BASE_URL,
 } from '../services/api';
+import type { RoleAssignmentErrorDetails } from '../services/api';
 import type {
   Player,
   Room,
@@ -42,6 +44,7 @@ import type {
   PlayerDisconnectedPayload,
   NicknameChangedPayload,
   OwnerChangedPayload,
+  RoleSetChangedPayload,
   RoundStartedPayload,
   TimerTickPayload,
   RoundEndedPayload,
@@ -447,6 +450,16 @@ export function RoomPage() {
           break;
         }
 
+        case 'ROLE_SET_CHANGED': {
+          const { roleConfigId, roleConfigRevision, selectedRoles, customRoleConfig } =
+            lastMessage.payload as RoleSetChangedPayload;
+          setRoom((prev) => {
+            if (!prev) return prev;
+            return { ...prev, roleConfigId, roleConfigRevision, selectedRoles, customRoleConfig };
+          });
+          break;
+        }
+
         case 'GAME_STARTED': {
           // Reset loading state
           setIsStarting(false);
@@ -783,11 +796,44 @@ export function RoomPage() {
       await startGame(roomCode);
       // Navigation will happen automatically via GAME_STARTED WebSocket message
     } catch (err) {
+      if (err instanceof APIError && err.code === 'INVALID_ROLE_DISTRIBUTION') {
+        await offerRoleAutoFix(err);
+        return;
+      }
       alert(err instanceof APIError ? err.userMessage : '게임 시작에 실패했습니다');
       setIsStarting(false);
     }
   };
 
+  // Roles cannot be dealt: show the problems and, if auto-fix can resolve them, its changes
+  const offerRoleAutoFix = async (err: APIError) => {
+    if (!roomCode) return;
+
+    const details = err.details as RoleAssignmentErrorDetails | undefined;
+    const problems = (details?.errors ?? []).map((message) => `- ${message}`).join('\n');
+    const fix = details?.fix ?? [];
+    if (fix.length === 0) {
+      alert(`${err.userMessage}\n\n${problems}`);
+      setIsStarting(false);
+      return;
+    }
+
+    const changes = fix
+      .map((change) => `- ${change.roleId}: ${change.from} → ${change.to} (${change.reason})`)
+      .join('\n');
+    if (!confirm(`${err.userMessage}\n\n${problems}\n\n다음과 같이 자동으로 조정하고 시작할까요?\n${changes}`)) {
+      setIsStarting(false);
+      return;
+    }
+
+    try {
+      await startGame(roomCode, true);
+    } catch (retryErr) {
+      alert(retryErr instanceof APIError ? retryErr.userMessage : '게임 시작에 실패했습니다');
+      setIsStarting(false);
+    }
+  };
+
   const handleResetGame = async () => {
     if (!roomCode) return;
 
@@ -1537,6 +1583,17 @@ export function RoomPage() {
           <PlayerList players={room.players} currentPlayerId={currentPlayer.id} />
         </div>
 
+        {!room.customRoleConfig && (
+          <div style={{ marginBottom: '1.5rem' }}>
+            <RoleDistributionPreview
+              roleConfigId={room.roleConfigId || 'standard'}
+              revision={room.roleConfigRevision}
+              playerCount={room.players.length}
+              selectedRoles={room.selectedRoles}
+            />
+          </div>
+        )}
+
         <div style={{ display: 'flex', gap: '1rem' }}>
           <button
             onClick={handleBackToHome}
@@ -23,6 +23,8 @@ const ERROR_MESSAGES: Record<string, string> = {
 
   // Game errors
   INSUFFICIENT_PLAYERS: '게임을 시작하려면 최소 6명의 플레이어가 필요합니다.',
+  INVALID_ROLE_DISTRIBUTION: '현재 역할 구성으로는 역할을 배분할 수 없습니다.',
+  ROLE_CONFIG_NOT_FOUND: '방의 역할 설정을 찾을 수 없습니다.',
   GAME_NOT_STARTED: '게임이 시작되지 않았습니다.',
 
   // Role config errors
@@ -181,8 +183,25 @@ export interface StartGameResponse extends CreateRoomResponse {
   };
 }
 
-export async function startGame(roomCode: string): Promise<StartGameResponse> {
-  return api.post<StartGameResponse>(`/api/v1/rooms/${roomCode}/game/start`);
+// A change auto-fix makes to the role selection before dealing
+export interface RoleCountChange {
+  roleId: string;
+  from: number;
+  to: number;
+  reason: string;
+}
+
+// details of an INVALID_ROLE_DISTRIBUTION or ROLE_CONFIG_NOT_FOUND error
+export interface RoleAssignmentErrorDetails {
+  errors: string[];
+  fix: RoleCountChange[] | null; // Changes auto-fix would make, if it can resolve every error
+}
+
+export async function startGame(roomCode: string, autoFix = false): Promise<StartGameResponse> {
+  return api.post<StartGameResponse>(
+    `/api/v1/rooms/${roomCode}/game/start`,
+    autoFix ? { autoFix } : undefined
+  );
 }
 
 // T094: Implement resetGame API function
@@ -292,6 +311,46 @@ export async function getRoleConfig(configId: string): Promise<RoleConfig> {
   return api.get<RoleConfig>(`/api/v1/role-configs/${configId}`);
 }
 
+// Role distribution preview: the cards each team is dealt for a player count
+export interface RoleCardCount {
+  roleId: string;
+  name: string;
+  nameKo: string;
+  count: number;
+}
+
+export interface TeamDistribution {
+  team: 'RED' | 'BLUE' | 'GREY' | 'ZOMBIE';
+  size: number;
+  roles: RoleCardCount[];
+  fill: number;
+  fillRoleId?: string;
+}
+
+export interface RoleDistribution {
+  configId: string;
+  revision?: number;
+  playerCount: number;
+  buried: boolean;
+  cardCount: number;
+  teams: TeamDistribution[];
+  errors: string[];
+  warnings: string[];
+}
+
+export async function previewRoleDistribution(
+  configId: string,
+  playerCount: number,
+  selectedRoles?: Record<string, number>,
+  revision?: number
+): Promise<RoleDistribution> {
+  return api.post<RoleDistribution>(`/api/v1/role-configs/${configId}/preview`, {
+    playerCount,
+    selectedRoles,
+    revision,
+  });
+}
+
 // Round management API functions
 export interface TransferLeadershipRequest {
   newLeaderId: string;
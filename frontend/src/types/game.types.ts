@@ -10,6 +10,8 @@ export interface Role {
   nameKo?: string;
   description: string;
   descriptionKo?: string;
+  names?: Record<string, string>; // Keyed by locale: en, ko, ja
+  descriptions?: Record<string, string>;
   team: TeamColor;
   icon?: string;
   isSpy: boolean;
@@ -26,6 +28,7 @@ export interface Player {
   role?: Role;
   team?: TeamColor;
   currentRoom?: RoomColor;
+  locale?: string;
   connectedAt: string;
 }
 
@@ -48,7 +51,9 @@ export interface Room {
   players: Player[];
   maxPlayers: number;
   roleConfigId?: string;
+  roleConfigRevision?: number;
   selectedRoles?: Record<string, number>;
+  customRoleConfig?: unknown; // Inline role set; previewed by the server only for saved configurations
   gameSession?: GameSession;
   createdAt: string;
   updatedAt: string;
@@ -129,6 +134,7 @@ export type WSMessageType =
   | 'PLAYER_DISCONNECTED'
   | 'NICKNAME_CHANGED'
   | 'OWNER_CHANGED'
+  | 'ROLE_SET_CHANGED'
   | 'GAME_STARTED'
   | 'ROLE_ASSIGNED'
   | 'GAME_RESET'
@@ -182,6 +188,13 @@ export interface OwnerChangedPayload {
   newOwner: Player;
 }
 
+export interface RoleSetChangedPayload {
+  roleConfigId: string;
+  roleConfigRevision?: number;
+  selectedRoles?: Record<string, number>;
+  customRoleConfig?: unknown;
+}
+
 export interface GameStartedPayload {
   gameSession: GameSession;
 }
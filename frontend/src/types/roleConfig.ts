@@ -13,6 +13,14 @@ export interface RoleConfigsResponse {
   configs: RoleConfigMeta[];
 }
 
+// Role count: fixed, by player range ({"6-9": 1, "10+": 2}) or a formula of the player count
+// ("players/5", or {"formula": "players/5", "min": 1, "max": 4})
+export type RoleCount =
+  | number
+  | string
+  | Record<string, number>
+  | { formula: string; min?: number; max?: number };
+
 // Full role definition
 export interface RoleDefinition {
   id: string;
@@ -22,7 +30,9 @@ export interface RoleDefinition {
   type: 'leader' | 'spy' | 'support' | 'standard';
   description?: string;
   descriptionKo?: string;
-  count: number | Record<string, number>;
+  names?: Record<string, string>; // Keyed by locale: en, ko, ja
+  descriptions?: Record<string, string>;
+  count: RoleCount;
   minPlayers: number;
   priority: number;
   color?: string;
@@ -31,5 +41,6 @@ export interface RoleDefinition {
 
 // Full role configuration with all roles
 export interface RoleConfig extends RoleConfigMeta {
+  extends?: string; // Configuration this one was built from; roles are always fully expanded
   roles: RoleDefinition[];
 }